@@ -3,7 +3,6 @@ package cmd
 import (
 	"fmt"
 	"io"
-	"log"
 	"os"
 
 	"github.com/Yakitrak/obsidian-cli/pkg/actions"
@@ -28,11 +27,11 @@ Examples:
   echo "piped content" | obsidian-cli append "My Note"`,
 	Args: cobra.RangeArgs(1, 2),
 	Run: func(cmd *cobra.Command, args []string) {
-		vault := obsidian.Vault{Name: vaultName}
+		vault := currentVault()
 		originalNoteName := args[0]
 		noteName, err := ResolveNoteName(&vault, originalNoteName)
 		if err != nil {
-			log.Fatal(err)
+			fatal(err)
 		}
 
 		var content string
@@ -41,28 +40,33 @@ Examples:
 		} else if !term.IsTerminal(int(os.Stdin.Fd())) {
 			stdinBytes, err := io.ReadAll(os.Stdin)
 			if err != nil {
-				log.Fatalf("Failed to read from stdin: %v", err)
+				fatal(fmt.Errorf("Failed to read from stdin: %v", err))
 			}
 			content = string(stdinBytes)
 		}
 
 		if content == "" {
-			log.Fatal("No content provided. Pass as argument or pipe from stdin:\n  obsidian-cli append \"note\" \"content\"\n  echo \"content\" | obsidian-cli append \"note\"")
+			fatal(obsidian.InvalidArgument("No content provided. Pass as argument or pipe from stdin:\n  obsidian-cli append \"note\" \"content\"\n  echo \"content\" | obsidian-cli append \"note\""))
 		}
 
-		note := obsidian.Note{}
+		note := noteManager(&vault)
 
 		params := actions.AppendParams{
 			NoteName: noteName,
 			Content:  content,
 		}
 
-		output, err := actions.AppendToNote(&vault, &note, params)
+		output, err := actions.AppendToNote(&vault, note, params)
 		if err != nil {
-			log.Fatal(WrapDailyNoteError(originalNoteName, err))
+			fatal(WrapDailyNoteError(originalNoteName, err))
+		}
+		if printDryRun(&vault, note) {
+			return
 		}
 
-		fmt.Println(output)
+		printResult(messageResult{Note: noteName, Message: output}, func() {
+			fmt.Println(output)
+		})
 	},
 }
 
@@ -1,12 +1,11 @@
 package cmd
 
 import (
+	"fmt"
 	"io"
-	"log"
 	"os"
 
 	"github.com/Yakitrak/obsidian-cli/pkg/actions"
-	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
 	"github.com/spf13/cobra"
 	"golang.org/x/term"
 )
@@ -19,15 +18,18 @@ var createNoteCmd = &cobra.Command{
 	Short:   "Creates note in vault (use @daily for daily note). Reads from stdin if -c not provided.",
 	Args:    cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		vault := obsidian.Vault{Name: vaultName}
-		uri := obsidian.Uri{}
+		vault := currentVault()
+		uri := uriManager(&vault)
 		noteName, err := ResolveNoteName(&vault, args[0])
 		if err != nil {
-			log.Fatal(err)
+			fatal(err)
 		}
 		useEditor, err := cmd.Flags().GetBool("editor")
 		if err != nil {
-			log.Fatalf("Failed to parse --editor flag: %v", err)
+			fatal(fmt.Errorf("Failed to parse --editor flag: %v", err))
+		}
+		if shouldOpen && !useEditor && !dryRun {
+			requireRegisteredVault(&vault)
 		}
 
 		// Read from stdin if data is being piped and -c is not supplied
@@ -35,7 +37,7 @@ var createNoteCmd = &cobra.Command{
 		if content == "" && !term.IsTerminal(int(os.Stdin.Fd())) {
 			stdinBytes, err := io.ReadAll(os.Stdin)
 			if err != nil {
-				log.Fatalf("Failed to read from stdin: %v", err)
+				fatal(fmt.Errorf("Failed to read from stdin: %v", err))
 			}
 			noteContent = string(stdinBytes)
 		}
@@ -45,13 +47,18 @@ var createNoteCmd = &cobra.Command{
 			Content:         noteContent,
 			ShouldAppend:    false,
 			ShouldOverwrite: shouldOverwrite,
-			ShouldOpen:      shouldOpen,
+			ShouldOpen:      shouldOpen && !dryRun,
 			UseEditor:       useEditor,
 		}
-		err = actions.CreateNote(&vault, &uri, params)
+		note := noteManager(&vault)
+		err = actions.CreateNote(&vault, note, &uri, params)
 		if err != nil {
-			log.Fatal(err)
+			fatal(err)
+		}
+		if printDryRun(&vault, note) {
+			return
 		}
+		printResult(noteResult{Note: noteName}, func() {})
 	},
 }
 
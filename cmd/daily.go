@@ -1,10 +1,7 @@
 package cmd
 
 import (
-	"log"
-
 	"github.com/Yakitrak/obsidian-cli/pkg/actions"
-	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
 	"github.com/spf13/cobra"
 )
 
@@ -18,24 +15,27 @@ If daily note pattern is configured (via set-daily-pattern), uses that path.
 Otherwise falls back to Obsidian's native daily note handler.`,
 	Args: cobra.ExactArgs(0),
 	Run: func(cmd *cobra.Command, args []string) {
-		vault := obsidian.Vault{Name: vaultName}
-		uri := obsidian.Uri{}
+		vault := currentVault()
+		requireRegisteredVault(&vault)
+		uri := uriManager(&vault)
 
 		noteName, err := vault.ResolveDailyNote()
 		if err != nil {
 			// Fallback to obsidian://daily when pattern not configured
 			err = actions.DailyNote(&vault, &uri)
 			if err != nil {
-				log.Fatal(err)
+				fatal(err)
 			}
+			printResult(noteResult{}, func() {})
 			return
 		}
 
 		params := actions.OpenParams{NoteName: noteName}
-		err = actions.OpenNote(&vault, &uri, params)
+		err = actions.OpenNote(&vault, noteManager(&vault), &uri, params)
 		if err != nil {
-			log.Fatal(err)
+			fatal(err)
 		}
+		printResult(noteResult{Note: noteName}, func() {})
 	},
 }
 
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
@@ -20,8 +21,8 @@ func WrapDailyNoteError(originalNoteName string, err error) error {
 	if err == nil {
 		return nil
 	}
-	if obsidian.IsDailyReference(originalNoteName) && err.Error() == obsidian.NoteDoesNotExistError {
-		return fmt.Errorf("%s\nYou can create today's daily note with: obsidian create \"@daily\"", err.Error())
+	if obsidian.IsDailyReference(originalNoteName) && errors.Is(err, obsidian.ErrNoteNotFound) {
+		return fmt.Errorf("%w\nYou can create today's daily note with: obsidian create \"@daily\"", err)
 	}
 	return err
 }
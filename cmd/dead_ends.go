@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/actions"
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/spf13/cobra"
+)
+
+var deadEndsCmd = &cobra.Command{
+	Use:   "dead-ends",
+	Short: "List notes that link to no other note",
+	Long: `List the notes without links to other notes. Links to attachments, to notes
+that do not exist and to the note itself do not count.
+
+Use --folder to only report notes inside a folder and --exclude-daily to leave
+out daily notes matching the pattern set with set-daily-pattern.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		vault := currentVault()
+		note := obsidian.Note{}
+		params := actions.GraphScopeParams{Folder: graphFolder, ExcludeDaily: excludeDaily}
+		notes, err := actions.FindDeadEnds(&vault, &note, params)
+		if err != nil {
+			fatal(err)
+		}
+		printResult(notesResult{Notes: notes}, func() {
+			for _, n := range notes {
+				fmt.Println(n)
+			}
+		})
+	},
+}
+
+func init() {
+	addGraphScopeFlags(deadEndsCmd)
+	rootCmd.AddCommand(deadEndsCmd)
+}
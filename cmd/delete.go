@@ -1,32 +1,72 @@
 package cmd
 
 import (
+	"fmt"
+	"os"
+	"strings"
+
 	"github.com/Yakitrak/obsidian-cli/pkg/actions"
-	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
-	"log"
 
 	"github.com/spf13/cobra"
 )
 
+var deleteForce bool
+var deleteUnlink bool
+var deleteRedirect string
 var deleteCmd = &cobra.Command{
 	Use:     "delete",
 	Aliases: []string{"d"},
 	Short:   "Delete note in vault",
-	Args:    cobra.ExactArgs(1),
+	Long: `Delete a note. Like Obsidian, the note is moved to the system trash or to the
+.trash folder of the vault, or deleted permanently, depending on the "Deleted
+files" setting of the vault. Use trash restore to bring it back.
+
+A note that other notes link to is only deleted when you say what should happen
+to those links: --unlink turns them into plain text, --redirect points them at
+another note and --force leaves them broken.`,
+	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		vault := obsidian.Vault{Name: vaultName}
-		note := obsidian.Note{}
+		vault := currentVault()
+		note := noteManager(&vault)
 		notePath := args[0]
-		params := actions.DeleteParams{NotePath: notePath}
-		err := actions.DeleteNote(&vault, &note, params)
+		params := actions.DeleteParams{
+			NotePath: notePath,
+			Force:    deleteForce,
+			Unlink:   deleteUnlink,
+			Redirect: deleteRedirect,
+		}
+		result, err := actions.DeleteNote(&vault, note, params)
 		if err != nil {
-			log.Fatal(err)
+			fatal(err)
+		}
+		if printDryRun(&vault, note) {
+			return
 		}
+		printResult(result, func() {
+			if len(result.LinkedFrom) > 0 {
+				switch {
+				case deleteUnlink:
+					fmt.Printf("Removed links to the note from %d notes\n", len(result.LinkedFrom))
+				case deleteRedirect != "":
+					fmt.Printf("Redirected links in %d notes to %s\n", len(result.LinkedFrom), deleteRedirect)
+				default:
+					fmt.Fprintf(os.Stderr, "Warning: links to the note are now broken in %s\n", strings.Join(result.LinkedFrom, ", "))
+				}
+			}
+			if result.Trash != "" {
+				fmt.Println("Moved note to trash: ", result.Path)
+				return
+			}
+			fmt.Println("Deleted note: ", result.Path)
+		})
 	},
 }
 
 func init() {
 	deleteCmd.Flags().BoolVarP(&shouldOpen, "open", "o", false, "open new note")
+	deleteCmd.Flags().BoolVarP(&deleteForce, "force", "f", false, "delete the note even if other notes link to it")
+	deleteCmd.Flags().BoolVar(&deleteUnlink, "unlink", false, "turn links to the note into plain text")
+	deleteCmd.Flags().StringVar(&deleteRedirect, "redirect", "", "point links to the note at another note")
 	deleteCmd.Flags().StringVarP(&vaultName, "vault", "v", "", "vault name")
 	rootCmd.AddCommand(deleteCmd)
 }
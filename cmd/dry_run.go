@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+)
+
+var dryRun bool
+
+type dryRunResult struct {
+	DryRun  bool                  `json:"dry_run"`
+	Changes []obsidian.FileChange `json:"changes"`
+}
+
+// printDryRun prints the changes recorded by a --dry-run note manager as unified
+// diffs. It reports false, printing nothing, when changes were written instead.
+func printDryRun(vault obsidian.VaultManager, note obsidian.NoteManager) bool {
+	recorder, ok := note.(*obsidian.RecordingNote)
+	if !ok {
+		return false
+	}
+	vaultPath, err := vault.Path()
+	if err != nil {
+		fatal(err)
+	}
+
+	changes := recorder.Changes(vaultPath)
+	printResult(dryRunResult{DryRun: true, Changes: changes}, func() {
+		if len(changes) == 0 {
+			fmt.Println("No changes")
+			return
+		}
+		for _, change := range changes {
+			fmt.Println(formatChange(change))
+			fmt.Print(change.Diff)
+		}
+	})
+	return true
+}
+
+// formatChange prints a change as "status path", with the new path of moved files.
+func formatChange(change obsidian.FileChange) string {
+	if change.NewPath != "" {
+		return fmt.Sprintf("%s %s -> %s", change.Status, change.Path, change.NewPath)
+	}
+	return fmt.Sprintf("%s %s", change.Status, change.Path)
+}
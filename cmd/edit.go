@@ -1,10 +1,10 @@
 package cmd
 
 import (
+	"fmt"
 	"log"
 
 	"github.com/Yakitrak/obsidian-cli/pkg/actions"
-	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
 	"github.com/spf13/cobra"
 )
 
@@ -20,17 +20,17 @@ Examples:
   obsidian-cli edit "Project" "phase 1" "phase 2" -v work`,
 	Args: cobra.ExactArgs(3),
 	Run: func(cmd *cobra.Command, args []string) {
-		vault := obsidian.Vault{Name: vaultName}
-		note := obsidian.Note{}
+		vault := currentVault()
+		note := noteManager(&vault)
 		originalNoteName := args[0]
 		noteName, err := ResolveNoteName(&vault, originalNoteName)
 		if err != nil {
-			log.Fatal(err)
+			fatal(err)
 		}
 
 		replaceAll, err := cmd.Flags().GetBool("all")
 		if err != nil {
-			log.Fatalf("Failed to parse --all flag: %v", err)
+			fatal(fmt.Errorf("Failed to parse --all flag: %v", err))
 		}
 
 		params := actions.EditParams{
@@ -40,12 +40,17 @@ Examples:
 			ReplaceAll: replaceAll,
 		}
 
-		output, err := actions.EditNote(&vault, &note, params)
+		output, err := actions.EditNote(&vault, note, params)
 		if err != nil {
-			log.Fatal(WrapDailyNoteError(originalNoteName, err))
+			fatal(WrapDailyNoteError(originalNoteName, err))
+		}
+		if printDryRun(&vault, note) {
+			return
 		}
 
-		log.Println(output)
+		printResult(messageResult{Note: noteName, Message: output}, func() {
+			log.Println(output)
+		})
 	},
 }
 
@@ -2,10 +2,8 @@ package cmd
 
 import (
 	"fmt"
-	"log"
 
 	"github.com/Yakitrak/obsidian-cli/pkg/actions"
-	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
 	"github.com/spf13/cobra"
 )
 
@@ -31,8 +29,8 @@ Examples:
 	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		noteName := args[0]
-		vault := obsidian.Vault{Name: vaultName}
-		note := obsidian.Note{}
+		vault := currentVault()
+		note := noteManager(&vault)
 
 		params := actions.FrontmatterParams{
 			NoteName: noteName,
@@ -43,14 +41,28 @@ Examples:
 			Value:    fmValue,
 		}
 
-		output, err := actions.Frontmatter(&vault, &note, params)
-		if err != nil {
-			log.Fatal(err)
+		if jsonOutput() && fmPrint {
+			fm, err := actions.ReadFrontmatter(&vault, note, noteName)
+			if err != nil {
+				fatal(err)
+			}
+			writeJSON(frontmatterResult{Note: noteName, Frontmatter: fm})
+			return
 		}
 
-		if output != "" {
-			fmt.Print(output)
+		output, err := actions.Frontmatter(&vault, note, params)
+		if err != nil {
+			fatal(err)
 		}
+		if !fmPrint && printDryRun(&vault, note) {
+			return
+		}
+
+		printResult(messageResult{Note: noteName, Message: output}, func() {
+			if output != "" {
+				fmt.Print(output)
+			}
+		})
 	},
 }
 
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/actions"
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/spf13/cobra"
+)
+
+var graphFormat string
+var graphTags bool
+var graphAttachments bool
+
+var graphCmd = &cobra.Command{
+	Use:   "graph",
+	Short: "Inspect and export the vault link graph",
+}
+
+var graphExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the vault link graph as DOT, GraphML or JSON",
+	Long: `Write the link graph of the vault to stdout. Every note is a node with its
+frontmatter as attributes, and links and embeds between notes are edges
+weighted by how often they occur.
+
+--tags adds tags as nodes connected to the notes using them, and
+--attachments adds the attachments notes link to or embed.
+
+Formats:
+  dot      Graphviz digraph
+  graphml  GraphML, e.g. for Gephi
+  json     {"nodes": [...], "edges": [...]}, also used with --output json`,
+	Example: `  obsidian-cli graph export --format dot | dot -Tsvg > vault.svg
+  obsidian-cli graph export --format graphml --tags > vault.graphml`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		// --output json selects the JSON format unless another one is asked for
+		if jsonOutput() {
+			if cmd.Flags().Changed("format") && graphFormat != actions.FormatJSON {
+				fatal(obsidian.InvalidArgument("--format %s cannot be used with --output json", graphFormat))
+			}
+			graphFormat = actions.FormatJSON
+		}
+		if err := actions.ValidateGraphFormat(graphFormat); err != nil {
+			fatal(err)
+		}
+		vault := currentVault()
+		note := obsidian.Note{}
+		graph, err := actions.ExportGraph(&vault, &note, actions.GraphExportParams{
+			Tags:        graphTags,
+			Attachments: graphAttachments,
+		})
+		if err != nil {
+			fatal(err)
+		}
+		if err := actions.WriteGraph(os.Stdout, graphFormat, graph); err != nil {
+			fatal(err)
+		}
+	},
+}
+
+func init() {
+	graphExportCmd.Flags().StringVarP(&vaultName, "vault", "v", "", "vault name")
+	graphExportCmd.Flags().StringVarP(&graphFormat, "format", "f", actions.FormatDOT, "graph format: dot, graphml or json")
+	graphExportCmd.Flags().BoolVar(&graphTags, "tags", false, "include tags as nodes")
+	graphExportCmd.Flags().BoolVar(&graphAttachments, "attachments", false, "include linked attachments as nodes")
+	graphCmd.AddCommand(graphExportCmd)
+	rootCmd.AddCommand(graphCmd)
+}
@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/actions"
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/spf13/cobra"
+)
+
+var undirectedPath bool
+var hubsLimit int
+var hubsBy string
+var clusterMethod string
+var clusterMinSize int
+
+type graphPathResult struct {
+	Path []string `json:"path"`
+}
+
+type graphHubsResult struct {
+	Hubs []obsidian.NoteRank `json:"hubs"`
+}
+
+type graphClustersResult struct {
+	Clusters [][]string `json:"clusters"`
+}
+
+var graphPathCmd = &cobra.Command{
+	Use:   "path <from> <to>",
+	Short: "Show the shortest chain of links between two notes",
+	Long: `Show the shortest chain of links leading from one note to another, one note
+per line. With --undirected, links may also be followed backwards, which shows
+how two notes relate even when neither links towards the other.`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		vault := currentVault()
+		note := obsidian.Note{}
+		path, err := actions.FindGraphPath(&vault, &note, actions.GraphPathParams{
+			From:       args[0],
+			To:         args[1],
+			Undirected: undirectedPath,
+		})
+		if err != nil {
+			fatal(err)
+		}
+		printResult(graphPathResult{Path: path}, func() {
+			fmt.Println(strings.Join(path, "\n-> "))
+		})
+	},
+}
+
+var graphHubsCmd = &cobra.Command{
+	Use:   "hubs",
+	Short: "List the most linked notes by PageRank or in-degree",
+	Long: `List the structural pillars of the vault: the notes with the highest PageRank
+(notes linked from other important notes rank higher) or, with --by in-degree,
+the notes with the most incoming links.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		vault := currentVault()
+		note := obsidian.Note{}
+		hubs, err := actions.FindHubs(&vault, &note, actions.GraphHubsParams{Limit: hubsLimit, By: hubsBy})
+		if err != nil {
+			fatal(err)
+		}
+		printResult(graphHubsResult{Hubs: hubs}, func() {
+			for _, hub := range hubs {
+				fmt.Printf("%.4f  in:%-4d out:%-4d %s\n", hub.PageRank, hub.InDegree, hub.OutDegree, hub.Note)
+			}
+		})
+	},
+}
+
+var graphClustersCmd = &cobra.Command{
+	Use:   "clusters",
+	Short: "Group notes into connected components or communities",
+	Long: `Group notes that are linked together, largest group first. By default groups
+are connected components: notes reachable from each other through links in
+either direction. --method communities splits them further into densely linked
+communities by greedy modularity optimisation (as in the Louvain method).`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		vault := currentVault()
+		note := obsidian.Note{}
+		clusters, err := actions.FindClusters(&vault, &note, actions.GraphClustersParams{
+			Method:  clusterMethod,
+			MinSize: clusterMinSize,
+		})
+		if err != nil {
+			fatal(err)
+		}
+		printResult(graphClustersResult{Clusters: clusters}, func() {
+			for i, cluster := range clusters {
+				if i > 0 {
+					fmt.Println()
+				}
+				fmt.Printf("Cluster %d (%d notes)\n", i+1, len(cluster))
+				for _, n := range cluster {
+					fmt.Println("  " + n)
+				}
+			}
+		})
+	},
+}
+
+func init() {
+	graphPathCmd.Flags().StringVarP(&vaultName, "vault", "v", "", "vault name")
+	graphPathCmd.Flags().BoolVarP(&undirectedPath, "undirected", "u", false, "also follow links backwards")
+	graphCmd.AddCommand(graphPathCmd)
+
+	graphHubsCmd.Flags().StringVarP(&vaultName, "vault", "v", "", "vault name")
+	graphHubsCmd.Flags().IntVarP(&hubsLimit, "limit", "n", 10, "number of notes to list, 0 for all")
+	graphHubsCmd.Flags().StringVar(&hubsBy, "by", actions.RankByPageRank, "ranking: pagerank or in-degree")
+	graphCmd.AddCommand(graphHubsCmd)
+
+	graphClustersCmd.Flags().StringVarP(&vaultName, "vault", "v", "", "vault name")
+	graphClustersCmd.Flags().StringVar(&clusterMethod, "method", actions.ClusterComponents, "grouping: components or communities")
+	graphClustersCmd.Flags().IntVar(&clusterMinSize, "min-size", 2, "leave out groups with fewer notes")
+	graphCmd.AddCommand(graphClustersCmd)
+}
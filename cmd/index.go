@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/actions"
+	"github.com/spf13/cobra"
+)
+
+var indexCmd = &cobra.Command{
+	Use:   "index",
+	Short: "Manage the cached note index of a vault",
+	Long: `Manage the cached note index of a vault.
+
+Commands read note paths, headings, links, tags and frontmatter from an index
+stored in the CLI config directory. The index is refreshed automatically for
+notes whose modification time or size changed.
+
+Examples:
+  obsidian-cli index status
+  obsidian-cli index rebuild -v work`,
+}
+
+var indexStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the state of the note index",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		runIndex(actions.IndexParams{})
+	},
+}
+
+var indexRebuildCmd = &cobra.Command{
+	Use:   "rebuild",
+	Short: "Rebuild the note index from scratch",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		runIndex(actions.IndexParams{Rebuild: true})
+	},
+}
+
+func runIndex(params actions.IndexParams) {
+	vault := currentVault()
+	status, err := actions.Index(&vault, params)
+	if err != nil {
+		fatal(err)
+	}
+
+	if jsonOutput() {
+		writeJSON(status)
+		return
+	}
+
+	fmt.Println("Vault path:    ", status.VaultPath)
+	fmt.Println("Index file:    ", status.IndexFile)
+	if !status.Exists {
+		fmt.Println("Index has not been built yet")
+		return
+	}
+	fmt.Println("Indexed notes: ", status.Notes)
+	fmt.Println("Last updated:  ", status.UpdatedAt.Format("2006-01-02 15:04:05"))
+	fmt.Printf("Pending:        %d added, %d modified, %d removed\n", status.Added, status.Modified, status.Removed)
+}
+
+func init() {
+	indexCmd.PersistentFlags().StringVarP(&vaultName, "vault", "v", "", "vault name")
+	indexCmd.AddCommand(indexStatusCmd)
+	indexCmd.AddCommand(indexRebuildCmd)
+	rootCmd.AddCommand(indexCmd)
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/actions"
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/spf13/cobra"
+)
+
+type linksResult struct {
+	Note  string                  `json:"note"`
+	Links []obsidian.ResolvedLink `json:"links"`
+}
+
+var linksCmd = &cobra.Command{
+	Use:   "links <note>",
+	Short: "List the outgoing links of a note",
+	Long: `List the links a note contains: wikilinks, embeds and markdown links, with the
+heading, block or alias they carry. Each link is resolved to the note or
+attachment it points to; links without a target are marked as missing.
+Links inside code blocks and inline code are ignored.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		vault := currentVault()
+		originalNoteName := args[0]
+		noteName, err := ResolveNoteName(&vault, originalNoteName)
+		if err != nil {
+			fatal(err)
+		}
+		note := obsidian.Note{}
+		links, err := actions.ListLinks(&vault, &note, actions.LinksParams{NoteName: noteName})
+		if err != nil {
+			fatal(WrapDailyNoteError(originalNoteName, err))
+		}
+		if links == nil {
+			links = []obsidian.ResolvedLink{}
+		}
+		printResult(linksResult{Note: noteName, Links: links}, func() {
+			for _, link := range links {
+				fmt.Println(formatLink(link))
+			}
+		})
+	},
+}
+
+// formatLink prints a link as "line: [[target#heading]] -> path", or "(missing)" when unresolved.
+func formatLink(link obsidian.ResolvedLink) string {
+	destination := "(missing)"
+	if link.Exists {
+		destination = link.Path
+	}
+	return fmt.Sprintf("%d: %s -> %s", link.Line, formatLinkText(link.Link), destination)
+}
+
+// formatLinkText writes a link back in the syntax it was found in.
+func formatLinkText(link obsidian.Link) string {
+	text := link.Target
+	if link.Heading != "" {
+		text += "#" + link.Heading
+	}
+	if link.Block != "" {
+		text += "#^" + link.Block
+	}
+	if link.Kind == obsidian.WikiLinkKind {
+		text = "[[" + text + "]]"
+	} else {
+		text = "[" + link.Alias + "](" + text + ")"
+	}
+	if link.Embed {
+		text = "!" + text
+	}
+	return text
+}
+
+func init() {
+	linksCmd.Flags().StringVarP(&vaultName, "vault", "v", "", "vault name")
+	rootCmd.AddCommand(linksCmd)
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/actions"
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/spf13/cobra"
+)
+
+var fixLinks bool
+
+var brokenLinkReasons = map[string]string{
+	obsidian.MissingNote:    "note not found",
+	obsidian.MissingFile:    "file not found",
+	obsidian.MissingHeading: "heading not found",
+	obsidian.MissingBlock:   "block not found",
+}
+
+var linksCheckCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Report broken links in the vault",
+	Long: `Scan every note and report the links whose target note, attachment, heading
+(#Heading) or block (#^id) does not exist, with the file and line they are on.
+Links to missing notes come with the notes whose names are closest.
+
+With --fix, links to a missing note are rewritten to the closest note when
+there is exactly one closest match. Links are rewritten the same way move
+updates them. Exits with status 1 when broken links remain.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		vault := currentVault()
+		note := noteManager(&vault)
+		result, err := actions.CheckLinks(&vault, note, actions.LinksCheckParams{Fix: fixLinks})
+		if err != nil {
+			fatal(err)
+		}
+		if fixLinks && printDryRun(&vault, note) {
+			return
+		}
+		if result.Broken == nil {
+			result.Broken = []obsidian.BrokenLink{}
+		}
+		printResult(result, func() {
+			for _, fix := range result.Fixed {
+				fmt.Printf("Fixed links to %q: now pointing to %s\n", fix.Target, fix.Path)
+			}
+			for _, link := range result.Broken {
+				fmt.Println(formatBrokenLink(link))
+			}
+		})
+		if len(result.Broken) > 0 {
+			os.Exit(obsidian.ExitCodeError)
+		}
+	},
+}
+
+// formatBrokenLink prints a broken link as "path:line: link (reason)" followed by suggestions.
+func formatBrokenLink(link obsidian.BrokenLink) string {
+	text := fmt.Sprintf("%s:%d: %s (%s)", link.Source, link.Line, formatLinkText(link.Link), brokenLinkReasons[link.Reason])
+	if len(link.Suggestions) > 0 {
+		text += fmt.Sprintf(", did you mean %s?", strings.Join(link.Suggestions, ", "))
+	}
+	return text
+}
+
+func init() {
+	linksCheckCmd.Flags().StringVarP(&vaultName, "vault", "v", "", "vault name")
+	linksCheckCmd.Flags().BoolVar(&fixLinks, "fix", false, "rewrite links to missing notes to the closest matching note")
+	linksCmd.AddCommand(linksCheckCmd)
+}
@@ -2,7 +2,6 @@ package cmd
 
 import (
 	"fmt"
-	"log"
 
 	"github.com/Yakitrak/obsidian-cli/pkg/actions"
 	"github.com/Yakitrak/obsidian-cli/pkg/frontmatter"
@@ -40,23 +39,28 @@ Examples:
 		if len(metadataFlags) > 0 {
 			metadataFilters, err = frontmatter.ParseFilters(metadataFlags)
 			if err != nil {
-				log.Fatal(err)
+				fatal(obsidian.InvalidArgument("%v", err))
 			}
 		}
 
-		vault := obsidian.Vault{Name: vaultName}
+		vault := currentVault()
 		entries, err := actions.ListEntries(&vault, actions.ListParams{
 			Path:            targetPath,
 			FullPath:        fullPath,
 			MetadataFilters: metadataFilters,
 		})
 		if err != nil {
-			log.Fatal(err)
+			fatal(err)
 		}
 
-		for _, entry := range entries {
-			fmt.Printf("• %s\n", entry)
+		if entries == nil {
+			entries = []string{}
 		}
+		printResult(listResult{Entries: entries}, func() {
+			for _, entry := range entries {
+				fmt.Printf("• %s\n", entry)
+			}
+		})
 	},
 }
 
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/actions"
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/spf13/cobra"
+)
+
+var unlinkedMentions bool
+var linkMentions bool
+var selectedMentions []int
+
+type mentionsResult struct {
+	Note string `json:"note"`
+	actions.MentionsResult
+}
+
+var mentionsCmd = &cobra.Command{
+	Use:   "mentions <note>",
+	Short: "List the mentions of a note in other notes",
+	Long: `List the lines of other notes that link to a note.
+
+With --unlinked, list the places where other notes mention the note's name or
+one of its frontmatter aliases in plain text instead. Matches are
+case-insensitive and whole-word; frontmatter, code, existing links and URLs are
+skipped.
+
+With --link, the unlinked mentions are turned into links in place: [[Note]]
+when the text matches the note name, [[Note|text]] otherwise. Use --select with
+the numbers of the listed mentions to only link some of them.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		vault := currentVault()
+		originalNoteName := args[0]
+		noteName, err := ResolveNoteName(&vault, originalNoteName)
+		if err != nil {
+			fatal(err)
+		}
+		note := noteManager(&vault)
+		params := actions.MentionsParams{
+			NoteName: noteName,
+			Unlinked: unlinkedMentions,
+			Link:     linkMentions,
+			Select:   selectedMentions,
+		}
+		result, err := actions.FindMentions(&vault, note, params)
+		if err != nil {
+			fatal(WrapDailyNoteError(originalNoteName, err))
+		}
+		if linkMentions && printDryRun(&vault, note) {
+			return
+		}
+		if result.Mentions == nil {
+			result.Mentions = []obsidian.Mention{}
+		}
+		printResult(mentionsResult{Note: noteName, MentionsResult: result}, func() {
+			if linkMentions {
+				for _, mention := range result.Linked {
+					fmt.Printf("Linked %s:%d:%d: %s\n", mention.Source, mention.Line, mention.Column, mention.Text)
+				}
+				return
+			}
+			for i, mention := range result.Mentions {
+				fmt.Println(formatMention(i+1, mention))
+			}
+		})
+	},
+}
+
+// formatMention prints a mention as "n) path:line: context".
+func formatMention(position int, mention obsidian.Mention) string {
+	location := fmt.Sprintf("%s:%d", mention.Source, mention.Line)
+	if mention.Column > 0 {
+		location += fmt.Sprintf(":%d", mention.Column)
+	}
+	return fmt.Sprintf("%d) %s: %s", position, location, mention.Context)
+}
+
+func init() {
+	mentionsCmd.Flags().StringVarP(&vaultName, "vault", "v", "", "vault name")
+	mentionsCmd.Flags().BoolVarP(&unlinkedMentions, "unlinked", "u", false, "list plain-text mentions of the note name and aliases")
+	mentionsCmd.Flags().BoolVar(&linkMentions, "link", false, "turn unlinked mentions into links")
+	mentionsCmd.Flags().IntSliceVar(&selectedMentions, "select", nil, "numbers of the unlinked mentions to link (default all)")
+	rootCmd.AddCommand(mentionsCmd)
+}
@@ -1,45 +1,80 @@
 package cmd
 
 import (
+	"fmt"
 	"github.com/Yakitrak/obsidian-cli/pkg/actions"
 	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
-	"log"
 
 	"github.com/spf13/cobra"
 )
 
 var shouldOpen bool
+var moveFolder bool
 var moveCmd = &cobra.Command{
 	Use:     "move",
 	Aliases: []string{"m"},
 	Short:   "Move or rename note in vault and updated corresponding links",
-	Args:    cobra.ExactArgs(2),
+	Long: `Move or rename a note and update the links pointing to it.
+
+With --folder, move or rename a whole folder instead. Parent folders of the new
+location are created as needed, and links written with the vault path of a
+note or attachment inside the folder are updated in every note.`,
+	Args: cobra.ExactArgs(2),
 	Run: func(cmd *cobra.Command, args []string) {
 		currentName := args[0]
 		newName := args[1]
-		vault := obsidian.Vault{Name: vaultName}
-		note := obsidian.Note{}
-		uri := obsidian.Uri{}
+		vault := currentVault()
+		note := noteManager(&vault)
+		if moveFolder {
+			if shouldOpen {
+				fatal(obsidian.InvalidArgument("--open cannot be used with --folder"))
+			}
+			result, err := actions.MoveFolder(&vault, note, actions.MoveFolderParams{
+				CurrentFolder: currentName,
+				NewFolder:     newName,
+			})
+			if err != nil {
+				fatal(err)
+			}
+			if printDryRun(&vault, note) {
+				return
+			}
+			printResult(result, func() {
+				fmt.Printf("Moved folder \nfrom %s\nto %s\n", result.From, result.To)
+			})
+			return
+		}
+		uri := uriManager(&vault)
 		useEditor, err := cmd.Flags().GetBool("editor")
 		if err != nil {
-			log.Fatalf("Failed to parse --editor flag: %v", err)
+			fatal(fmt.Errorf("Failed to parse --editor flag: %v", err))
+		}
+		if shouldOpen && !useEditor && !dryRun {
+			requireRegisteredVault(&vault)
 		}
 		params := actions.MoveParams{
 			CurrentNoteName: currentName,
 			NewNoteName:     newName,
-			ShouldOpen:      shouldOpen,
+			ShouldOpen:      shouldOpen && !dryRun,
 			UseEditor:       useEditor,
 		}
-		err = actions.MoveNote(&vault, &note, &uri, params)
+		result, err := actions.MoveNote(&vault, note, &uri, params)
 		if err != nil {
-			log.Fatal(err)
+			fatal(err)
+		}
+		if printDryRun(&vault, note) {
+			return
 		}
+		printResult(result, func() {
+			fmt.Printf("Moved note \nfrom %s\nto %s\n", result.From, result.To)
+		})
 
 	},
 }
 
 func init() {
 	moveCmd.Flags().BoolVarP(&shouldOpen, "open", "o", false, "open new note")
+	moveCmd.Flags().BoolVar(&moveFolder, "folder", false, "move a folder and update links to the notes inside it")
 	moveCmd.Flags().StringVarP(&vaultName, "vault", "v", "", "vault name")
 	moveCmd.Flags().BoolP("editor", "e", false, "open in editor instead of Obsidian (requires --open flag)")
 	rootCmd.AddCommand(moveCmd)
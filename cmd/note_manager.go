@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+)
+
+// noteManager returns the note manager of commands that change notes. Changes
+// are recorded in the journal of the vault so they can be undone. With
+// --dry-run, changes are recorded in memory instead of written.
+func noteManager(vault obsidian.VaultManager) obsidian.NoteManager {
+	if dryRun {
+		return obsidian.NewRecordingNote()
+	}
+	note := &obsidian.Note{}
+	// Without a vault path the command fails before it changes anything
+	if vaultPath, err := vault.Path(); err == nil {
+		note.Journal = obsidian.NewJournal(vaultPath, commandLine())
+	}
+	return note
+}
+
+// commandLine returns the arguments of the running command as they could be typed again.
+func commandLine() string {
+	args := make([]string, len(os.Args)-1)
+	for i, arg := range os.Args[1:] {
+		if arg == "" || strings.ContainsAny(arg, " \t\n\"'") {
+			arg = strconv.Quote(arg)
+		}
+		args[i] = arg
+	}
+	return strings.Join(args, " ")
+}
@@ -1,10 +1,7 @@
 package cmd
 
 import (
-	"log"
-
 	"github.com/Yakitrak/obsidian-cli/pkg/actions"
-	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
 	"github.com/spf13/cobra"
 )
 
@@ -17,18 +14,20 @@ var OpenVaultCmd = &cobra.Command{
 	Short:   "Opens note in vault by note name (use @daily for daily note)",
 	Args:    cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		vault := obsidian.Vault{Name: vaultName}
-		uri := obsidian.Uri{}
+		vault := currentVault()
+		requireRegisteredVault(&vault)
+		uri := uriManager(&vault)
 		originalNoteName := args[0]
 		noteName, err := ResolveNoteName(&vault, originalNoteName)
 		if err != nil {
-			log.Fatal(err)
+			fatal(err)
 		}
 		params := actions.OpenParams{NoteName: noteName, Section: sectionName, CreateIfNotExist: createIfNotExist}
-		err = actions.OpenNote(&vault, &uri, params)
+		err = actions.OpenNote(&vault, noteManager(&vault), &uri, params)
 		if err != nil {
-			log.Fatal(WrapDailyNoteError(originalNoteName, err))
+			fatal(WrapDailyNoteError(originalNoteName, err))
 		}
+		printResult(noteResult{Note: noteName, Section: sectionName}, func() {})
 	},
 }
 
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/actions"
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/spf13/cobra"
+)
+
+var graphFolder string
+var excludeDaily bool
+
+var orphansCmd = &cobra.Command{
+	Use:   "orphans",
+	Short: "List notes no other note links to",
+	Long: `List the notes that no other note links to. Links are resolved like Obsidian
+does; links from a note to itself do not count.
+
+Use --folder to only report notes inside a folder (links from anywhere in the
+vault still count) and --exclude-daily to leave out daily notes matching the
+pattern set with set-daily-pattern.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		vault := currentVault()
+		note := obsidian.Note{}
+		params := actions.GraphScopeParams{Folder: graphFolder, ExcludeDaily: excludeDaily}
+		notes, err := actions.FindOrphans(&vault, &note, params)
+		if err != nil {
+			fatal(err)
+		}
+		printResult(notesResult{Notes: notes}, func() {
+			for _, n := range notes {
+				fmt.Println(n)
+			}
+		})
+	},
+}
+
+func addGraphScopeFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&vaultName, "vault", "v", "", "vault name")
+	cmd.Flags().StringVarP(&graphFolder, "folder", "f", "", "only report notes in this folder")
+	cmd.Flags().BoolVar(&excludeDaily, "exclude-daily", false, "leave out daily notes matching the daily note pattern")
+}
+
+func init() {
+	addGraphScopeFlags(orphansCmd)
+	rootCmd.AddCommand(orphansCmd)
+}
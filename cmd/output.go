@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/actions"
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+)
+
+const (
+	outputText = "text"
+	outputJSON = "json"
+)
+
+var outputFormat string
+
+// errorResult is printed instead of log output when a command fails with --output json.
+type errorResult struct {
+	Error errorDetail `json:"error"`
+}
+
+type errorDetail struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// messageResult is the JSON result of commands that only report what they changed.
+type messageResult struct {
+	Note    string `json:"note"`
+	Message string `json:"message"`
+}
+
+// noteResult is the JSON result of commands that create or open a note.
+type noteResult struct {
+	Note    string `json:"note,omitempty"`
+	Section string `json:"section,omitempty"`
+}
+
+type printNoteResult struct {
+	Note     string `json:"note"`
+	Contents string `json:"contents"`
+}
+
+type frontmatterResult struct {
+	Note        string                 `json:"note"`
+	Frontmatter map[string]interface{} `json:"frontmatter"`
+}
+
+type vaultResult struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+}
+
+type dailyPatternResult struct {
+	Pattern string `json:"pattern"`
+	Example string `json:"example"`
+}
+
+type listResult struct {
+	Entries []string `json:"entries"`
+}
+
+type notesResult struct {
+	Notes []string `json:"notes"`
+}
+
+type searchResults struct {
+	Results []actions.SearchResult `json:"results"`
+}
+
+func jsonOutput() bool {
+	return outputFormat == outputJSON
+}
+
+func validateOutputFormat() error {
+	if outputFormat != outputText && outputFormat != outputJSON {
+		return fmt.Errorf("unsupported output %q, expected %s or %s", outputFormat, outputText, outputJSON)
+	}
+	return nil
+}
+
+// printResult writes result as JSON with --output json, otherwise it calls printText.
+func printResult(result interface{}, printText func()) {
+	if !jsonOutput() {
+		printText()
+		return
+	}
+	writeJSON(result)
+}
+
+func writeJSON(value interface{}) {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(value); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// fatal reports err and exits with the exit code documented for its kind. With
+// --output json the error is printed to stdout as an object with a stable code so
+// scripts do not have to parse log messages.
+func fatal(err error) {
+	fatalWithCode(err, obsidian.ErrorCode(err), obsidian.ExitCode(err))
+}
+
+func fatalWithCode(err error, code string, exitCode int) {
+	if jsonOutput() {
+		writeJSON(errorResult{Error: errorDetail{Code: code, Message: err.Error()}})
+	} else {
+		log.Print(err)
+	}
+	os.Exit(exitCode)
+}
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"github.com/Yakitrak/obsidian-cli/pkg/actions"
 	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
-	"log"
 
 	"github.com/spf13/cobra"
 )
@@ -18,11 +17,11 @@ var printCmd = &cobra.Command{
 	Short:   "Print contents of note",
 	Args:    cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		vault := obsidian.Vault{Name: vaultName}
+		vault := currentVault()
 		originalNoteName := args[0]
 		noteName, err := ResolveNoteName(&vault, originalNoteName)
 		if err != nil {
-			log.Fatal(err)
+			fatal(err)
 		}
 		note := obsidian.Note{}
 		params := actions.PrintParams{
@@ -31,9 +30,11 @@ var printCmd = &cobra.Command{
 		}
 		contents, err := actions.PrintNote(&vault, &note, params)
 		if err != nil {
-			log.Fatal(WrapDailyNoteError(originalNoteName, err))
+			fatal(WrapDailyNoteError(originalNoteName, err))
 		}
-		fmt.Println(contents)
+		printResult(printNoteResult{Note: noteName, Contents: contents}, func() {
+			fmt.Println(contents)
+		})
 	},
 }
 
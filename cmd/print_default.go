@@ -2,7 +2,6 @@ package cmd
 
 import (
 	"fmt"
-	"log"
 
 	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
 	"github.com/spf13/cobra"
@@ -18,20 +17,22 @@ var printDefaultCmd = &cobra.Command{
 		vault := obsidian.Vault{}
 		name, err := vault.DefaultName()
 		if err != nil {
-			log.Fatal(err)
+			fatal(err)
 		}
 		path, err := vault.Path()
 		if err != nil {
-			log.Fatal(err)
+			fatal(err)
 		}
 
-		if printPathOnly {
-			fmt.Print(path)
-			return
-		}
+		printResult(vaultResult{Name: name, Path: path}, func() {
+			if printPathOnly {
+				fmt.Print(path)
+				return
+			}
 
-		fmt.Println("Default vault name: ", name)
-		fmt.Println("Default vault path: ", path)
+			fmt.Println("Default vault name: ", name)
+			fmt.Println("Default vault path: ", path)
+		})
 	},
 }
 
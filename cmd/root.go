@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
 	"github.com/spf13/cobra"
 )
 
@@ -12,11 +13,26 @@ var rootCmd = &cobra.Command{
 	Short:   "obsidian-cli - CLI to open, search, move, create, delete and update notes",
 	Version: "v0.2.3",
 	Long:    "obsidian-cli - CLI to open, search, move, create, delete and update notes",
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := validateVaultFlags(); err != nil {
+			return err
+		}
+		return validateOutputFormat()
+	},
 }
 
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
+		if jsonOutput() {
+			fatalWithCode(err, obsidian.ErrorCodeInvalidArgument, obsidian.ExitCodeUsage)
+		}
 		fmt.Fprintf(os.Stderr, "Whoops. There was an error while executing your CLI '%s'", err)
-		os.Exit(1)
+		os.Exit(obsidian.ExitCodeUsage)
 	}
 }
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&outputFormat, "output", outputText, "output format: text or json")
+	rootCmd.PersistentFlags().StringVar(&vaultPath, "vault-path", "", "folder of a vault, which does not have to be registered in Obsidian")
+	rootCmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "print the changes as a diff instead of writing them")
+}
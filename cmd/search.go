@@ -1,8 +1,6 @@
 package cmd
 
 import (
-	"log"
-
 	"github.com/Yakitrak/obsidian-cli/pkg/actions"
 	"github.com/Yakitrak/obsidian-cli/pkg/frontmatter"
 	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
@@ -23,26 +21,48 @@ Examples:
   obsidian-cli search --meta status=active
 
   # Search notes with multiple filters
-  obsidian-cli search --meta status=active --meta type=project`,
+  obsidian-cli search --meta status=active --meta type=project
+
+  # Print every matching note as JSON for scripts
+  obsidian-cli search --meta status=active --format json`,
 	Args: cobra.NoArgs,
 	Run: func(cmd *cobra.Command, args []string) {
-		vault := obsidian.Vault{Name: vaultName}
+		vault := currentVault()
 		note := obsidian.Note{}
 		fuzzyFinder := obsidian.FuzzyFinder{}
 		metadataFlags, _ := cmd.Flags().GetStringSlice("meta")
+		noInteractive, _ := cmd.Flags().GetBool("no-interactive")
+		format, _ := cmd.Flags().GetString("format")
 
 		var metadataFilters map[string]string
 		var err error
 		if len(metadataFlags) > 0 {
 			metadataFilters, err = frontmatter.ParseFilters(metadataFlags)
 			if err != nil {
-				log.Fatal(err)
+				fatal(obsidian.InvalidArgument("%v", err))
+			}
+		}
+
+		params := actions.SearchParams{
+			MetadataFilters: metadataFilters,
+			NoInteractive:   noInteractive,
+			Format:          format,
+		}
+		if jsonOutput() {
+			notes, err := actions.FindNotes(&vault, &note, params)
+			if err != nil {
+				fatal(err)
+			}
+			if notes == nil {
+				notes = []string{}
 			}
+			writeJSON(notesResult{Notes: notes})
+			return
 		}
 
-		err = actions.SearchNotes(&vault, &note, &fuzzyFinder, metadataFilters)
+		err = actions.SearchNotes(&vault, &note, &fuzzyFinder, params)
 		if err != nil {
-			log.Fatal(err)
+			fatal(err)
 		}
 	},
 }
@@ -50,5 +70,7 @@ Examples:
 func init() {
 	searchCmd.Flags().StringVarP(&vaultName, "vault", "v", "", "vault name")
 	searchCmd.Flags().StringSliceP("meta", "m", []string{}, "filter by frontmatter metadata (key=value)")
+	searchCmd.Flags().Bool("no-interactive", false, "print every match instead of opening the fuzzy finder")
+	searchCmd.Flags().String("format", "", "print every match as json, ndjson or tsv (implies --no-interactive)")
 	rootCmd.AddCommand(searchCmd)
 }
@@ -1,8 +1,6 @@
 package cmd
 
 import (
-	"log"
-
 	"github.com/Yakitrak/obsidian-cli/pkg/actions"
 	"github.com/Yakitrak/obsidian-cli/pkg/frontmatter"
 	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
@@ -11,30 +9,87 @@ import (
 )
 
 var searchContentCmd = &cobra.Command{
-	Use:     "search-content [search term]",
-	Short:   "Search note content and print matching note path",
+	Use:   "search-content [search term]",
+	Short: "Search note content and print matching note path",
+	Long: `Search note titles, headings and content and print the matching note path.
+
+Notes must contain every word of the query; use quotes for exact phrases.
+Results are ranked by relevance (BM25), with matches in the title and
+headings weighted above matches in the body.
+
+The query supports Obsidian's search operators:
+  -term             exclude notes matching term
+  a OR b, (...)     alternatives and groups
+  path:text         vault path contains text
+  file:text         file name contains text
+  tag:#tag          note has the tag or a nested tag
+  content:(...)     match the note content only
+  line:(a b)        every term on the same line
+  section:(a b)     every term in the same section
+  [prop], [prop:v]  frontmatter property exists or contains v
+
+Use --regex to match a regular expression against every line and file name
+instead, --case-sensitive to match the exact case and --word to only match
+whole words.
+
+Use --no-interactive to print every match instead of opening the fuzzy
+finder, or --format json|ndjson|tsv to print path, line, snippet and score
+for scripts.
+
+Examples:
+  obsidian-cli search-content "meeting notes"
+  obsidian-cli search-content '"release checklist" ios'
+  obsidian-cli search-content "budget" --limit 10
+  obsidian-cli search-content 'tag:#work line:(budget TODO) -path:archive'
+  obsidian-cli search-content --regex '[A-Z]+-[0-9]+' --case-sensitive
+  obsidian-cli search-content "budget" --format ndjson | jq .path`,
 	Args:    cobra.ExactArgs(1),
 	Aliases: []string{"sc"},
 	Run: func(cmd *cobra.Command, args []string) {
-		vault := obsidian.Vault{Name: vaultName}
+		vault := currentVault()
 		note := obsidian.Note{}
 		fuzzyFinder := obsidian.FuzzyFinder{}
 
 		searchTerm := args[0]
 		metadataFlags, _ := cmd.Flags().GetStringSlice("meta")
+		noInteractive, _ := cmd.Flags().GetBool("no-interactive")
+		format, _ := cmd.Flags().GetString("format")
+		limit, _ := cmd.Flags().GetInt("limit")
+		regex, _ := cmd.Flags().GetBool("regex")
+		caseSensitive, _ := cmd.Flags().GetBool("case-sensitive")
+		word, _ := cmd.Flags().GetBool("word")
 
 		var metadataFilters map[string]string
 		var err error
 		if len(metadataFlags) > 0 {
 			metadataFilters, err = frontmatter.ParseFilters(metadataFlags)
 			if err != nil {
-				log.Fatal(err)
+				fatal(obsidian.InvalidArgument("%v", err))
+			}
+		}
+
+		params := actions.SearchContentParams{
+			SearchTerm:      searchTerm,
+			MetadataFilters: metadataFilters,
+			Limit:           limit,
+			Regex:           regex,
+			CaseSensitive:   caseSensitive,
+			Word:            word,
+			NoInteractive:   noInteractive,
+			Format:          format,
+		}
+		if jsonOutput() {
+			results, err := actions.FindNotesContent(&vault, &note, params)
+			if err != nil {
+				fatal(err)
 			}
+			writeJSON(searchResults{Results: results})
+			return
 		}
 
-		err = actions.SearchNotesContent(&vault, &note, &fuzzyFinder, searchTerm, metadataFilters)
+		err = actions.SearchNotesContent(&vault, &note, &fuzzyFinder, params)
 		if err != nil {
-			log.Fatal(err)
+			fatal(err)
 		}
 	},
 }
@@ -42,5 +97,11 @@ var searchContentCmd = &cobra.Command{
 func init() {
 	searchContentCmd.Flags().StringVarP(&vaultName, "vault", "v", "", "vault name")
 	searchContentCmd.Flags().StringSliceP("meta", "m", []string{}, "filter by frontmatter metadata (key=value)")
+	searchContentCmd.Flags().Bool("no-interactive", false, "print every match instead of opening the fuzzy finder")
+	searchContentCmd.Flags().String("format", "", "print every match as json, ndjson or tsv (implies --no-interactive)")
+	searchContentCmd.Flags().IntP("limit", "n", 0, "maximum number of notes to return (0 for all)")
+	searchContentCmd.Flags().BoolP("regex", "r", false, "treat the search term as a regular expression")
+	searchContentCmd.Flags().BoolP("case-sensitive", "c", false, "match the exact case of the search term")
+	searchContentCmd.Flags().BoolP("word", "w", false, "only match whole words")
 	rootCmd.AddCommand(searchContentCmd)
 }
@@ -2,7 +2,6 @@ package cmd
 
 import (
 	"fmt"
-	"log"
 	"time"
 
 	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
@@ -30,12 +29,14 @@ Examples:
 		pattern := args[0]
 		v := obsidian.Vault{}
 		if err := v.SetDailyNotePattern(pattern); err != nil {
-			log.Fatal(err)
+			fatal(err)
 		}
 
 		example := obsidian.ExpandDatePattern(pattern, time.Now())
-		fmt.Printf("Daily note pattern set to: %s\n", pattern)
-		fmt.Printf("Today's daily note would be: %s\n", example)
+		printResult(dailyPatternResult{Pattern: pattern, Example: example}, func() {
+			fmt.Printf("Daily note pattern set to: %s\n", pattern)
+			fmt.Printf("Today's daily note would be: %s\n", example)
+		})
 	},
 }
 
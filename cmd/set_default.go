@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
 	"github.com/spf13/cobra"
-	"log"
 )
 
 var setDefaultCmd = &cobra.Command{
@@ -17,14 +16,16 @@ var setDefaultCmd = &cobra.Command{
 		v := obsidian.Vault{Name: name}
 		err := v.SetDefaultName(name)
 		if err != nil {
-			log.Fatal(err)
+			fatal(err)
 		}
 		path, err := v.Path()
 		if err != nil {
-			log.Fatal(err)
+			fatal(err)
 		}
-		fmt.Println("Default vault set to: ", name)
-		fmt.Println("Default vault path set to: ", path)
+		printResult(vaultResult{Name: name, Path: path}, func() {
+			fmt.Println("Default vault set to: ", name)
+			fmt.Println("Default vault path set to: ", path)
+		})
 
 	},
 }
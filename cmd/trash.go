@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/actions"
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/spf13/cobra"
+)
+
+type trashResult struct {
+	Notes []obsidian.TrashedNote `json:"notes"`
+}
+
+var trashCmd = &cobra.Command{
+	Use:   "trash",
+	Short: "List and restore deleted notes",
+	Long: `List and restore deleted notes.
+
+delete follows the "Deleted files" setting of the vault in Obsidian: notes are
+moved to the system trash, to the .trash folder of the vault, or deleted
+permanently. Notes in the .trash folder and, on Linux, notes of the vault in
+the system trash can be listed and restored.
+
+Examples:
+  obsidian-cli trash list
+  obsidian-cli trash restore "Projects/Plan"`,
+}
+
+var trashListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the deleted notes of the vault",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		vault := currentVault()
+		note := obsidian.Note{}
+		notes, err := actions.ListTrash(&vault, &note)
+		if err != nil {
+			fatal(err)
+		}
+		if notes == nil {
+			notes = []obsidian.TrashedNote{}
+		}
+		printResult(trashResult{Notes: notes}, func() {
+			for _, n := range notes {
+				fmt.Println(formatTrashedNote(n))
+			}
+		})
+	},
+}
+
+var trashRestoreCmd = &cobra.Command{
+	Use:   "restore <note>",
+	Short: "Move a deleted note back to where it was",
+	Long: `Move a deleted note back to where it was in the vault. The note is given by
+its path in the vault or its name; if it was deleted more than once, the most
+recent copy is restored.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		vault := currentVault()
+		note := noteManager(&vault)
+		restored, err := actions.RestoreTrash(&vault, note, actions.TrashRestoreParams{NoteName: args[0]})
+		if err != nil {
+			fatal(err)
+		}
+		if printDryRun(&vault, note) {
+			return
+		}
+		printResult(restored, func() {
+			fmt.Println("Restored note:", restored.Path)
+		})
+	},
+}
+
+// formatTrashedNote prints a deleted note as "path (trash, deleted at)".
+func formatTrashedNote(note obsidian.TrashedNote) string {
+	if note.DeletedAt == nil {
+		return fmt.Sprintf("%s (%s trash)", note.Path, note.Trash)
+	}
+	return fmt.Sprintf("%s (%s trash, deleted %s)", note.Path, note.Trash, note.DeletedAt.Format("2006-01-02 15:04:05"))
+}
+
+func init() {
+	trashCmd.PersistentFlags().StringVarP(&vaultName, "vault", "v", "", "vault name")
+	trashCmd.AddCommand(trashListCmd)
+	trashCmd.AddCommand(trashRestoreCmd)
+	rootCmd.AddCommand(trashCmd)
+}
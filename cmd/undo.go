@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/actions"
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/spf13/cobra"
+)
+
+var forceUndo bool
+var historyLimit int
+
+type operationsResult struct {
+	Operations []obsidian.JournalOperation `json:"operations"`
+}
+
+var undoCmd = &cobra.Command{
+	Use:   "undo [count]",
+	Short: "Revert the last changes made to the vault",
+	Long: `Revert the last operations made to the vault by commands such as move, edit,
+create, delete or links check --fix. Every change the CLI writes is recorded in
+a journal in the CLI config directory, with the previous content of each file.
+Use history to list the recorded operations.
+
+An operation is only reverted if the files it changed were not changed since.
+Use --force to revert it anyway, overwriting the later changes. All reverted
+operations are applied together: if one cannot be reverted, nothing is.`,
+	Example: `  obsidian-cli undo
+  obsidian-cli undo 3`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if dryRun {
+			fatal(obsidian.InvalidArgument("undo cannot be used with --dry-run, use history to see the operations it would revert"))
+		}
+		count := 1
+		if len(args) == 1 {
+			n, err := strconv.Atoi(args[0])
+			if err != nil || n < 1 {
+				fatal(obsidian.InvalidArgument("invalid count %q, expected a positive number", args[0]))
+			}
+			count = n
+		}
+
+		vault := currentVault()
+		operations, err := actions.Undo(&vault, actions.UndoParams{Count: count, Force: forceUndo})
+		if err != nil {
+			fatal(err)
+		}
+		printResult(operationsResult{Operations: operations}, func() {
+			for _, operation := range operations {
+				fmt.Printf("Undid %s\n", formatOperation(operation))
+			}
+		})
+	},
+}
+
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "List the changes made to the vault that can be undone",
+	Long: `List the operations recorded in the journal of the vault, most recent first,
+with the files each one changed. The first one listed is reverted by undo.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		vault := currentVault()
+		operations, err := actions.History(&vault, actions.HistoryParams{Limit: historyLimit})
+		if err != nil {
+			fatal(err)
+		}
+		printResult(operationsResult{Operations: operations}, func() {
+			if len(operations) == 0 {
+				fmt.Println("No changes recorded")
+				return
+			}
+			for _, operation := range operations {
+				fmt.Println(formatOperation(operation))
+				for _, change := range operation.Changes {
+					fmt.Printf("  %s\n", formatChange(obsidian.FileChange{Path: change.Path, NewPath: change.NewPath, Status: change.Status}))
+				}
+			}
+		})
+	},
+}
+
+// formatOperation prints an operation as "#id time command (n files)".
+func formatOperation(operation obsidian.JournalOperation) string {
+	files := "files"
+	if len(operation.Changes) == 1 {
+		files = "file"
+	}
+	return fmt.Sprintf("#%d %s %s (%d %s)", operation.ID, operation.Time.Format("2006-01-02 15:04:05"), operation.Command, len(operation.Changes), files)
+}
+
+func init() {
+	undoCmd.Flags().StringVarP(&vaultName, "vault", "v", "", "vault name")
+	undoCmd.Flags().BoolVarP(&forceUndo, "force", "f", false, "revert files that were changed since")
+	historyCmd.Flags().StringVarP(&vaultName, "vault", "v", "", "vault name")
+	historyCmd.Flags().IntVarP(&historyLimit, "limit", "n", 0, "only list the most recent operations")
+	rootCmd.AddCommand(undoCmd)
+	rootCmd.AddCommand(historyCmd)
+}
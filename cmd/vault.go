@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/spf13/cobra"
+)
+
+type vaultsResult struct {
+	Vaults []obsidian.VaultInfo `json:"vaults"`
+}
+
+type vaultInitResult struct {
+	Path  string              `json:"path"`
+	Vault *obsidian.VaultInfo `json:"vault,omitempty"`
+}
+
+var registerVault bool
+
+var vaultCmd = &cobra.Command{
+	Use:     "vault",
+	Aliases: []string{"vaults"},
+	Short:   "List, create and register vaults",
+	Long: `List the vaults known to Obsidian, create new vaults and add or remove them
+in Obsidian's config.
+
+Commands find a vault given with --vault by its ID in Obsidian's config, by the
+name of its folder or by its full path. If several vaults share a folder name,
+use the ID or path shown by vault list.
+
+Examples:
+  obsidian-cli vault list
+  obsidian-cli list --vault 3f2a9c1d0b8e7a65
+  obsidian-cli vault init ~/notes --register`,
+}
+
+var vaultListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List the vaults with their ID and path",
+	Args:    cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		vaults, err := obsidian.ListVaults()
+		if err != nil {
+			fatal(err)
+		}
+		printResult(vaultsResult{Vaults: vaults}, func() {
+			for _, v := range vaults {
+				fmt.Println(formatVault(v))
+			}
+		})
+	},
+}
+
+var vaultInitCmd = &cobra.Command{
+	Use:   "init <path>",
+	Short: "Create a vault folder with an empty Obsidian config",
+	Long: `Create a vault: the folder at path, created if needed, gets an .obsidian folder
+with empty settings. With --register the vault is also added to Obsidian's config.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		path, err := obsidian.InitVault(args[0])
+		if err != nil {
+			fatal(err)
+		}
+		result := vaultInitResult{Path: path}
+		if registerVault {
+			vault, err := obsidian.RegisterVault(path)
+			if err != nil {
+				fatal(err)
+			}
+			result.Vault = &vault
+		}
+		printResult(result, func() {
+			fmt.Println("Created vault at", path)
+			if result.Vault != nil {
+				fmt.Println("Registered vault", result.Vault.ID)
+			}
+		})
+	},
+}
+
+var vaultRegisterCmd = &cobra.Command{
+	Use:   "register <path>",
+	Short: "Add a vault folder to Obsidian's config",
+	Long: `Add the vault folder at path to Obsidian's config under a new ID, so Obsidian
+and the --vault flag know it. A folder that is already registered keeps its ID.
+Quit Obsidian first: it rewrites its config when it exits.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		vault, err := obsidian.RegisterVault(args[0])
+		if err != nil {
+			fatal(err)
+		}
+		printResult(vault, func() {
+			fmt.Println(formatVault(vault))
+		})
+	},
+}
+
+var vaultUnregisterCmd = &cobra.Command{
+	Use:   "unregister <vault>",
+	Short: "Remove a vault from Obsidian's config",
+	Long: `Remove a vault, given by ID, folder name or path, from Obsidian's config. The
+vault folder and its notes are kept. Quit Obsidian first: it rewrites its
+config when it exits.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		vault, err := obsidian.UnregisterVault(args[0])
+		if err != nil {
+			fatal(err)
+		}
+		printResult(vault, func() {
+			fmt.Println("Unregistered vault", vault.Name, "at", vault.Path)
+		})
+	},
+}
+
+// formatVault prints a vault on one line, the default vault of the CLI marked
+// with an asterisk.
+func formatVault(v obsidian.VaultInfo) string {
+	marker := " "
+	if v.Default {
+		marker = "*"
+	}
+	line := fmt.Sprintf("%s %s\t%s\t%s", marker, v.Name, v.ID, v.Path)
+	if v.Open {
+		line += "\t(open)"
+	}
+	return line
+}
+
+func init() {
+	vaultInitCmd.Flags().BoolVar(&registerVault, "register", false, "also add the vault to Obsidian's config")
+	vaultCmd.AddCommand(vaultListCmd)
+	vaultCmd.AddCommand(vaultInitCmd)
+	vaultCmd.AddCommand(vaultRegisterCmd)
+	vaultCmd.AddCommand(vaultUnregisterCmd)
+	rootCmd.AddCommand(vaultCmd)
+}
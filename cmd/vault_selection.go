@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+)
+
+var vaultPath string
+
+// currentVault returns the vault the command works on. Flags win over the
+// environment, and a vault given by path wins over one given by name. Without
+// either, the default vault is used.
+func currentVault() obsidian.Vault {
+	switch {
+	case vaultPath != "":
+		return obsidian.Vault{Dir: vaultPath}
+	case vaultName != "":
+		return obsidian.Vault{Name: vaultName}
+	case os.Getenv("OBSIDIAN_VAULT_PATH") != "":
+		return obsidian.Vault{Dir: os.Getenv("OBSIDIAN_VAULT_PATH")}
+	}
+	return obsidian.Vault{Name: os.Getenv("OBSIDIAN_VAULT")}
+}
+
+// uriManager returns the URI manager used to hand notes to Obsidian, which
+// fails for vaults given by path since Obsidian does not know them.
+func uriManager(vault *obsidian.Vault) obsidian.Uri {
+	if vault.Dir != "" {
+		return obsidian.Uri{Err: obsidian.ErrVaultNotRegistered}
+	}
+	return obsidian.Uri{}
+}
+
+func validateVaultFlags() error {
+	if vaultPath != "" && vaultName != "" {
+		return fmt.Errorf("--vault and --vault-path cannot be used together")
+	}
+	return nil
+}
+
+// requireRegisteredVault stops commands that open Obsidian before they change
+// anything when the vault is given by path.
+func requireRegisteredVault(vault *obsidian.Vault) {
+	if vault.Dir != "" {
+		fatal(obsidian.ErrVaultNotRegistered)
+	}
+}
@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCurrentVault(t *testing.T) {
+	tests := []struct {
+		testName  string
+		name      string
+		path      string
+		envName   string
+		envPath   string
+		wantVault obsidian.Vault
+	}{
+		{"Default vault", "", "", "", "", obsidian.Vault{}},
+		{"Vault flag", "notes", "", "", "", obsidian.Vault{Name: "notes"}},
+		{"Vault path flag", "", "/vaults/notes", "", "", obsidian.Vault{Dir: "/vaults/notes"}},
+		{"Vault flag wins over the environment", "notes", "", "env", "/vaults/env", obsidian.Vault{Name: "notes"}},
+		{"Vault path flag wins over the environment", "", "/vaults/notes", "env", "/vaults/env", obsidian.Vault{Dir: "/vaults/notes"}},
+		{"OBSIDIAN_VAULT_PATH wins over OBSIDIAN_VAULT", "", "", "env", "/vaults/env", obsidian.Vault{Dir: "/vaults/env"}},
+		{"OBSIDIAN_VAULT", "", "", "env", "", obsidian.Vault{Name: "env"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testName, func(t *testing.T) {
+			// Arrange
+			originalName, originalPath := vaultName, vaultPath
+			defer func() { vaultName, vaultPath = originalName, originalPath }()
+			vaultName, vaultPath = test.name, test.path
+			t.Setenv("OBSIDIAN_VAULT", test.envName)
+			t.Setenv("OBSIDIAN_VAULT_PATH", test.envPath)
+			// Act
+			vault := currentVault()
+			// Assert
+			assert.Equal(t, test.wantVault, vault)
+		})
+	}
+}
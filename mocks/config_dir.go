@@ -1,7 +1,12 @@
 package mocks
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/config"
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
 )
 
 func CreateMockObsidianConfigFile(t *testing.T) string {
@@ -15,3 +20,21 @@ func CreateMockCliConfigDirectories(t *testing.T) (string, string) {
 	tmpDir := t.TempDir()
 	return tmpDir, tmpDir + "/preferences.json"
 }
+
+// RunWithTempConfig runs the tests of a package with the config directory, where
+// vault indexes are written, and the system trash in a temporary folder, so the
+// tests leave the user's files alone. It returns the exit code for TestMain.
+func RunWithTempConfig(m *testing.M) int {
+	configDir, err := os.MkdirTemp("", "obsidian-cli-test")
+	if err != nil {
+		panic(err)
+	}
+	defer os.RemoveAll(configDir)
+	config.UserConfigDirectory = func() (string, error) {
+		return configDir, nil
+	}
+	obsidian.SystemTrashDir = func() string {
+		return filepath.Join(configDir, "Trash")
+	}
+	return m.Run()
+}
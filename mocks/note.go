@@ -3,29 +3,96 @@ package mocks
 import "github.com/Yakitrak/obsidian-cli/pkg/obsidian"
 
 type MockNoteManager struct {
-	DeleteErr          error
-	MoveErr            error
-	UpdateLinksError   error
-	GetContentsError   error
-	SetContentsError   error
-	FindBacklinksErr   error
+	DeleteErr           error
+	TrashLocation       string
+	TrashedNotes        []obsidian.TrashedNote
+	TrashErr            error
+	CreateErr           error
+	MoveErr             error
+	MovedFolders        [][2]string
+	UpdateLinksError    error
+	GetContentsError    error
+	SetContentsError    error
+	FindBacklinksErr    error
 	FindBacklinksResult []obsidian.NoteMatch
-	NoMatches          bool
-	Contents           string
+	OutgoingLinksErr    error
+	OutgoingLinksResult []obsidian.ResolvedLink
+	BrokenLinksErr      error
+	BrokenLinksResult   []obsidian.BrokenLink
+	UpdatedLinks        [][2]string
+	UnlinkedNotes       []string
+	ResolvedNote        string
+	ResolveNoteErr      error
+	LinkGraphErr        error
+	LinkGraph           *obsidian.LinkGraph
+	GraphExportErr      error
+	GraphExport         *obsidian.GraphExport
+	GraphExportOptions  obsidian.GraphExportOptions
+	MentionsErr         error
+	MentionsResult      []obsidian.Mention
+	LinkMentionsErr     error
+	LinkedMentions      []obsidian.Mention
+	NoMatches           bool
+	Contents            string
+}
+
+func (m *MockNoteManager) Transact(fn func() error) error {
+	return fn()
+}
+
+func (m *MockNoteManager) Create(string, string, bool) error {
+	return m.CreateErr
 }
 
 func (m *MockNoteManager) Delete(string) error {
 	return m.DeleteErr
 }
 
+func (m *MockNoteManager) Trash(string, string) (string, error) {
+	return m.TrashLocation, m.DeleteErr
+}
+
+func (m *MockNoteManager) ListTrash(string) ([]obsidian.TrashedNote, error) {
+	return m.TrashedNotes, m.TrashErr
+}
+
+func (m *MockNoteManager) RestoreTrash(string, string) (obsidian.TrashedNote, error) {
+	if m.TrashErr != nil || len(m.TrashedNotes) == 0 {
+		return obsidian.TrashedNote{}, m.TrashErr
+	}
+	return m.TrashedNotes[0], nil
+}
+
 func (m *MockNoteManager) Move(string, string) error {
 	return m.MoveErr
 }
 
-func (m *MockNoteManager) UpdateLinks(string, string, string) error {
+func (m *MockNoteManager) MoveFolder(string, string) error {
+	return m.MoveErr
+}
+
+func (m *MockNoteManager) UpdateFolderLinks(_ string, oldFolder string, newFolder string) error {
+	m.MovedFolders = append(m.MovedFolders, [2]string{oldFolder, newFolder})
+	return m.UpdateLinksError
+}
+
+func (m *MockNoteManager) UpdateLinks(_ string, oldNoteName string, newNoteName string) error {
+	m.UpdatedLinks = append(m.UpdatedLinks, [2]string{oldNoteName, newNoteName})
 	return m.UpdateLinksError
 }
 
+func (m *MockNoteManager) UnlinkNote(_ string, noteName string) error {
+	m.UnlinkedNotes = append(m.UnlinkedNotes, noteName)
+	return m.UpdateLinksError
+}
+
+func (m *MockNoteManager) ResolveNote(_ string, noteName string) (string, error) {
+	if m.ResolvedNote != "" {
+		return m.ResolvedNote, m.ResolveNoteErr
+	}
+	return noteName, m.ResolveNoteErr
+}
+
 func (m *MockNoteManager) GetContents(string, string) (string, error) {
 	if m.Contents != "" {
 		return m.Contents, m.GetContentsError
@@ -41,7 +108,7 @@ func (m *MockNoteManager) GetNotesList(string) ([]string, error) {
 	return []string{"note1", "note2", "note3"}, m.GetContentsError
 }
 
-func (m *MockNoteManager) SearchNotesWithSnippets(string, string) ([]obsidian.NoteMatch, error) {
+func (m *MockNoteManager) SearchNotesWithSnippets(string, string, obsidian.SearchOptions) ([]obsidian.NoteMatch, error) {
 	if m.GetContentsError != nil {
 		return nil, m.GetContentsError
 	}
@@ -69,3 +136,53 @@ func (m *MockNoteManager) FindBacklinks(string, string) ([]obsidian.NoteMatch, e
 		{FilePath: "another-note.md", LineNumber: 10, MatchLine: "Also references [[target]]"},
 	}, nil
 }
+
+func (m *MockNoteManager) OutgoingLinks(string, string) ([]obsidian.ResolvedLink, error) {
+	if m.OutgoingLinksErr != nil {
+		return nil, m.OutgoingLinksErr
+	}
+	return m.OutgoingLinksResult, nil
+}
+
+func (m *MockNoteManager) FindBrokenLinks(string) ([]obsidian.BrokenLink, error) {
+	if m.BrokenLinksErr != nil {
+		return nil, m.BrokenLinksErr
+	}
+	return m.BrokenLinksResult, nil
+}
+
+func (m *MockNoteManager) GetLinkGraph(string) (*obsidian.LinkGraph, error) {
+	if m.LinkGraphErr != nil {
+		return nil, m.LinkGraphErr
+	}
+	if m.LinkGraph != nil {
+		return m.LinkGraph, nil
+	}
+	return &obsidian.LinkGraph{Outgoing: map[string][]string{}, Incoming: map[string][]string{}}, nil
+}
+
+func (m *MockNoteManager) ExportGraph(_ string, opts obsidian.GraphExportOptions) (*obsidian.GraphExport, error) {
+	m.GraphExportOptions = opts
+	if m.GraphExportErr != nil {
+		return nil, m.GraphExportErr
+	}
+	if m.GraphExport != nil {
+		return m.GraphExport, nil
+	}
+	return &obsidian.GraphExport{Nodes: []obsidian.GraphNode{}, Edges: []obsidian.GraphEdge{}}, nil
+}
+
+func (m *MockNoteManager) FindUnlinkedMentions(string, string) ([]obsidian.Mention, error) {
+	if m.MentionsErr != nil {
+		return nil, m.MentionsErr
+	}
+	return m.MentionsResult, nil
+}
+
+func (m *MockNoteManager) LinkMentions(_ string, _ string, mentions []obsidian.Mention) ([]obsidian.Mention, error) {
+	m.LinkedMentions = append(m.LinkedMentions, mentions...)
+	if m.LinkMentionsErr != nil {
+		return nil, m.LinkMentionsErr
+	}
+	return mentions, nil
+}
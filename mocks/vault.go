@@ -11,6 +11,7 @@ type MockVaultOperator struct {
 	PathError            error
 	DailyNotePatternErr  error
 	Name                 string
+	VaultID              string
 	DailyPattern         string
 	VaultPath            string
 }
@@ -39,6 +40,16 @@ func (m *MockVaultOperator) Path() (string, error) {
 	return "path", nil
 }
 
+func (m *MockVaultOperator) ID() (string, error) {
+	if m.DefaultNameErr != nil {
+		return "", m.DefaultNameErr
+	}
+	if m.VaultID != "" {
+		return m.VaultID, nil
+	}
+	return m.Name, nil
+}
+
 func (m *MockVaultOperator) DailyNotePattern() (string, error) {
 	if m.DailyNotePatternErr != nil {
 		return "", m.DailyNotePatternErr
@@ -3,7 +3,6 @@ package actions
 import (
 	"fmt"
 	"os"
-	"path/filepath"
 	"strings"
 
 	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
@@ -18,7 +17,7 @@ type CreateParams struct {
 	UseEditor       bool
 }
 
-func CreateNote(vault obsidian.VaultManager, uri obsidian.UriManager, params CreateParams) error {
+func CreateNote(vault obsidian.VaultManager, note obsidian.NoteManager, uri obsidian.UriManager, params CreateParams) error {
 	// Ensure vault name is resolved before getting path
 	_, err := vault.DefaultName()
 	if err != nil {
@@ -38,12 +37,6 @@ func CreateNote(vault obsidian.VaultManager, uri obsidian.UriManager, params Cre
 		return err
 	}
 
-	// Create parent directories if needed
-	dir := filepath.Dir(filePath)
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		return err
-	}
-
 	// Check if file exists
 	_, err = os.Stat(filePath)
 	fileExists := err == nil
@@ -52,20 +45,9 @@ func CreateNote(vault obsidian.VaultManager, uri obsidian.UriManager, params Cre
 		return fmt.Errorf("file %q already exists (use -o to overwrite or -a to append)", params.NoteName)
 	}
 
-	// Write directly to file
-	if params.ShouldAppend && fileExists {
-		f, err := os.OpenFile(filePath, os.O_APPEND|os.O_WRONLY, 0644)
-		if err != nil {
-			return err
-		}
-		defer f.Close()
-		if _, err := f.WriteString(normalizedContent); err != nil {
-			return err
-		}
-	} else {
-		if err := os.WriteFile(filePath, []byte(normalizedContent), 0644); err != nil {
-			return err
-		}
+	err = note.Create(filePath, normalizedContent, params.ShouldAppend && fileExists)
+	if err != nil {
+		return err
 	}
 
 	// Open in Obsidian or editor if requested
@@ -73,12 +55,12 @@ func CreateNote(vault obsidian.VaultManager, uri obsidian.UriManager, params Cre
 		if params.UseEditor {
 			return obsidian.OpenInEditor(filePath)
 		}
-		vaultName, err := vault.DefaultName()
+		vaultID, err := vault.ID()
 		if err != nil {
 			return err
 		}
 		obsidianUri := uri.Construct(ObsOpenUrl, map[string]string{
-			"vault": vaultName,
+			"vault": vaultID,
 			"file":  params.NoteName,
 		})
 		return uri.Execute(obsidianUri)
@@ -7,6 +7,7 @@ import (
 
 	"github.com/Yakitrak/obsidian-cli/mocks"
 	"github.com/Yakitrak/obsidian-cli/pkg/actions"
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -17,7 +18,7 @@ func TestCreateNote(t *testing.T) {
 		vault := mocks.MockVaultOperator{Name: "myVault", VaultPath: tmpDir}
 		uri := mocks.MockUriManager{}
 		// Act
-		err := actions.CreateNote(&vault, &uri, actions.CreateParams{
+		err := actions.CreateNote(&vault, &obsidian.Note{}, &uri, actions.CreateParams{
 			NoteName:  "note",
 			Content:   "test content",
 			UseEditor: false,
@@ -32,7 +33,7 @@ func TestCreateNote(t *testing.T) {
 			PathError: errors.New("Failed to get vault path"),
 		}
 		// Act
-		err := actions.CreateNote(&vault, &mocks.MockUriManager{}, actions.CreateParams{
+		err := actions.CreateNote(&vault, &obsidian.Note{}, &mocks.MockUriManager{}, actions.CreateParams{
 			NoteName:  "note-name",
 			Content:   "test",
 			UseEditor: false,
@@ -41,6 +42,20 @@ func TestCreateNote(t *testing.T) {
 		assert.Equal(t, vault.PathError, err)
 	})
 
+	t.Run("note.Create returns an error", func(t *testing.T) {
+		// Arrange
+		tmpDir := t.TempDir()
+		vault := mocks.MockVaultOperator{Name: "myVault", VaultPath: tmpDir}
+		note := mocks.MockNoteManager{CreateErr: obsidian.ErrWrite}
+		// Act
+		err := actions.CreateNote(&vault, &note, &mocks.MockUriManager{}, actions.CreateParams{
+			NoteName: "note",
+			Content:  "test",
+		})
+		// Assert
+		assert.ErrorIs(t, err, obsidian.ErrWrite)
+	})
+
 	t.Run("uri.Execute returns error when opening note", func(t *testing.T) {
 		// Arrange
 		tmpDir := t.TempDir()
@@ -49,7 +64,7 @@ func TestCreateNote(t *testing.T) {
 		}
 		vault := mocks.MockVaultOperator{Name: "myVault", VaultPath: tmpDir}
 		// Act
-		err := actions.CreateNote(&vault, &uri, actions.CreateParams{
+		err := actions.CreateNote(&vault, &obsidian.Note{}, &uri, actions.CreateParams{
 			NoteName:   "note-name",
 			Content:    "test",
 			ShouldOpen: true,
@@ -71,7 +86,7 @@ func TestCreateNote(t *testing.T) {
 		os.Setenv("EDITOR", "true")
 
 		// Act
-		err := actions.CreateNote(&vault, &uri, actions.CreateParams{
+		err := actions.CreateNote(&vault, &obsidian.Note{}, &uri, actions.CreateParams{
 			NoteName:   "note",
 			Content:    "test",
 			ShouldOpen: true,
@@ -94,7 +109,7 @@ func TestCreateNote(t *testing.T) {
 		os.Setenv("EDITOR", "false")
 
 		// Act
-		err := actions.CreateNote(&vault, &uri, actions.CreateParams{
+		err := actions.CreateNote(&vault, &obsidian.Note{}, &uri, actions.CreateParams{
 			NoteName:   "note",
 			Content:    "test",
 			ShouldOpen: true,
@@ -112,7 +127,7 @@ func TestCreateNote(t *testing.T) {
 		uri := mocks.MockUriManager{}
 
 		// Act - UseEditor is true but ShouldOpen is false
-		err := actions.CreateNote(&vault, &uri, actions.CreateParams{
+		err := actions.CreateNote(&vault, &obsidian.Note{}, &uri, actions.CreateParams{
 			NoteName:   "note",
 			Content:    "test",
 			ShouldOpen: false,
@@ -136,7 +151,7 @@ func TestCreateNote_FileExists(t *testing.T) {
 		uri := mocks.MockUriManager{}
 
 		// Act - try to create note without overwrite flag
-		err = actions.CreateNote(&vault, &uri, actions.CreateParams{
+		err = actions.CreateNote(&vault, &obsidian.Note{}, &uri, actions.CreateParams{
 			NoteName:        "existing-note",
 			Content:         "new content",
 			ShouldOverwrite: false,
@@ -163,7 +178,7 @@ func TestCreateNote_FileExists(t *testing.T) {
 		uri := mocks.MockUriManager{}
 
 		// Act - create note WITH overwrite flag
-		err = actions.CreateNote(&vault, &uri, actions.CreateParams{
+		err = actions.CreateNote(&vault, &obsidian.Note{}, &uri, actions.CreateParams{
 			NoteName:        "existing-note",
 			Content:         "new content",
 			ShouldOverwrite: true,
@@ -182,7 +197,7 @@ func TestCreateNote_FileExists(t *testing.T) {
 		uri := mocks.MockUriManager{}
 
 		// Act
-		err := actions.CreateNote(&vault, &uri, actions.CreateParams{
+		err := actions.CreateNote(&vault, &obsidian.Note{}, &uri, actions.CreateParams{
 			NoteName: "new-note",
 			Content:  "some content",
 		})
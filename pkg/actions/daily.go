@@ -5,13 +5,13 @@ import (
 )
 
 func DailyNote(vault obsidian.VaultManager, uri obsidian.UriManager) error {
-	vaultName, err := vault.DefaultName()
+	vaultID, err := vault.ID()
 	if err != nil {
 		return err
 	}
 
 	obsidianUri := uri.Construct(OnsDailyUrl, map[string]string{
-		"vault": vaultName,
+		"vault": vaultID,
 	})
 
 	err = uri.Execute(obsidianUri)
@@ -1,33 +1,119 @@
 package actions
 
 import (
+	"fmt"
+	"sort"
+	"strings"
+
 	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
 )
 
 type DeleteParams struct {
 	NotePath string
+	// Force deletes the note even though other notes link to it.
+	Force bool
+	// Unlink turns the links to the note into plain text.
+	Unlink bool
+	// Redirect points the links to the note at another note instead.
+	Redirect string
+}
+
+// DeleteResult holds the file path of a deleted note and where it was moved to,
+// unless the vault is set to delete files permanently. LinkedFrom lists the notes
+// that linked to it.
+type DeleteResult struct {
+	Path       string   `json:"path"`
+	Trash      string   `json:"trash,omitempty"`
+	LinkedFrom []string `json:"linked_from,omitempty"`
+}
+
+// LinkedNoteError is returned when a note that other notes link to is deleted
+// without saying what should happen to the links.
+type LinkedNoteError struct {
+	Note       string
+	LinkedFrom []string
 }
 
-func DeleteNote(vault obsidian.VaultManager, note obsidian.NoteManager, params DeleteParams) error {
+func (e *LinkedNoteError) Error() string {
+	return fmt.Sprintf("Note %q is linked from %s; use --force to delete it anyway, --unlink to turn the links into text or --redirect to point them at another note",
+		e.Note, strings.Join(e.LinkedFrom, ", "))
+}
+
+func DeleteNote(vault obsidian.VaultManager, note obsidian.NoteManager, params DeleteParams) (DeleteResult, error) {
 	_, err := vault.DefaultName()
 	if err != nil {
-		return err
+		return DeleteResult{}, err
 	}
 
 	vaultPath, err := vault.Path()
 	if err != nil {
-		return err
+		return DeleteResult{}, err
 	}
 
 	// Validate path stays within vault directory
 	notePath, err := obsidian.ValidatePath(vaultPath, params.NotePath)
 	if err != nil {
-		return err
+		return DeleteResult{}, err
 	}
 
-	err = note.Delete(notePath)
+	if params.Unlink && params.Redirect != "" {
+		return DeleteResult{}, obsidian.InvalidArgument("Use either --unlink or --redirect, not both")
+	}
+	if params.Redirect != "" {
+		redirectPath, err := obsidian.ValidatePath(vaultPath, params.Redirect)
+		if err != nil {
+			return DeleteResult{}, err
+		}
+		if obsidian.AddMdSuffix(redirectPath) == obsidian.AddMdSuffix(notePath) {
+			return DeleteResult{}, obsidian.InvalidArgument("Cannot redirect the links to the note that is deleted")
+		}
+		if _, err := note.GetContents(vaultPath, params.Redirect); err != nil {
+			return DeleteResult{}, err
+		}
+	}
+
+	backlinks, err := note.FindBacklinks(vaultPath, params.NotePath)
 	if err != nil {
+		return DeleteResult{}, err
+	}
+	linkedFrom := linkingNotes(backlinks)
+	if len(linkedFrom) > 0 && !params.Force && !params.Unlink && params.Redirect == "" {
+		return DeleteResult{}, &LinkedNoteError{Note: params.NotePath, LinkedFrom: linkedFrom}
+	}
+
+	// The note is only deleted if the links to it can be changed as well
+	var trash string
+	err = note.Transact(func() error {
+		switch {
+		case len(linkedFrom) == 0:
+		case params.Unlink:
+			if err := note.UnlinkNote(vaultPath, params.NotePath); err != nil {
+				return err
+			}
+		case params.Redirect != "":
+			if err := note.UpdateLinks(vaultPath, params.NotePath, params.Redirect); err != nil {
+				return err
+			}
+		}
+		trash, err = note.Trash(vaultPath, notePath)
 		return err
+	})
+	if err != nil {
+		return DeleteResult{}, err
+	}
+	return DeleteResult{Path: obsidian.AddMdSuffix(notePath), Trash: trash, LinkedFrom: linkedFrom}, nil
+}
+
+// linkingNotes returns the notes the backlinks are in, sorted and without duplicates.
+func linkingNotes(backlinks []obsidian.NoteMatch) []string {
+	var notes []string
+	seen := make(map[string]bool)
+	for _, backlink := range backlinks {
+		if !seen[backlink.FilePath] {
+			seen[backlink.FilePath] = true
+			notes = append(notes, backlink.FilePath)
+		}
 	}
-	return nil
+	sort.Strings(notes)
+	return notes
 }
@@ -5,6 +5,7 @@ import (
 	"github.com/Yakitrak/obsidian-cli/mocks"
 	"github.com/Yakitrak/obsidian-cli/pkg/actions"
 	"github.com/stretchr/testify/assert"
+	"path/filepath"
 	"testing"
 )
 
@@ -12,13 +13,27 @@ func TestDeleteNote(t *testing.T) {
 	t.Run("Successful delete note", func(t *testing.T) {
 		// Arrange
 		vault := mocks.MockVaultOperator{Name: "myVault"}
-		note := mocks.MockNoteManager{}
+		note := mocks.MockNoteManager{NoMatches: true}
 		// Act
-		err := actions.DeleteNote(&vault, &note, actions.DeleteParams{
+		result, err := actions.DeleteNote(&vault, &note, actions.DeleteParams{
 			NotePath: "noteToDelete",
 		})
 		// Assert
 		assert.NoError(t, err, "Expected no error")
+		assert.Equal(t, "noteToDelete.md", filepath.Base(result.Path))
+	})
+
+	t.Run("Reports where the note was moved to", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := mocks.MockNoteManager{TrashLocation: "/vault/.trash/noteToDelete.md", NoMatches: true}
+		// Act
+		result, err := actions.DeleteNote(&vault, &note, actions.DeleteParams{
+			NotePath: "noteToDelete",
+		})
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, note.TrashLocation, result.Trash)
 	})
 
 	t.Run("vault.DefaultName returns an error", func(t *testing.T) {
@@ -27,7 +42,7 @@ func TestDeleteNote(t *testing.T) {
 			DefaultNameErr: errors.New("Failed to get default vault name"),
 		}
 		// Act
-		err := actions.DeleteNote(&vault, &mocks.MockNoteManager{}, actions.DeleteParams{
+		_, err := actions.DeleteNote(&vault, &mocks.MockNoteManager{}, actions.DeleteParams{
 			NotePath: "noteToDelete",
 		})
 		// Assert
@@ -40,7 +55,7 @@ func TestDeleteNote(t *testing.T) {
 			PathError: errors.New("Failed to get vault path"),
 		}
 		// Act
-		err := actions.DeleteNote(&vault, &mocks.MockNoteManager{}, actions.DeleteParams{
+		_, err := actions.DeleteNote(&vault, &mocks.MockNoteManager{}, actions.DeleteParams{
 			NotePath: "noteToDelete",
 		})
 		// Assert
@@ -51,12 +66,104 @@ func TestDeleteNote(t *testing.T) {
 		// Arrange
 		note := mocks.MockNoteManager{
 			DeleteErr: errors.New("Could not delete"),
+			NoMatches: true,
 		}
 		// Act
-		err := actions.DeleteNote(&mocks.MockVaultOperator{}, &note, actions.DeleteParams{
+		_, err := actions.DeleteNote(&mocks.MockVaultOperator{}, &note, actions.DeleteParams{
 			NotePath: "noteToDelete",
 		})
 		// Assert
 		assert.Equal(t, note.DeleteErr, err)
 	})
+
+	t.Run("Refuses to delete a note other notes link to", func(t *testing.T) {
+		// Arrange
+		note := mocks.MockNoteManager{}
+		// Act
+		_, err := actions.DeleteNote(&mocks.MockVaultOperator{}, &note, actions.DeleteParams{
+			NotePath: "target",
+		})
+		// Assert
+		var linkedErr *actions.LinkedNoteError
+		if assert.ErrorAs(t, err, &linkedErr) {
+			assert.Equal(t, []string{"another-note.md", "linking-note.md"}, linkedErr.LinkedFrom)
+		}
+		assert.ErrorContains(t, err, "another-note.md, linking-note.md")
+	})
+
+	t.Run("Deletes a linked note with force", func(t *testing.T) {
+		// Arrange
+		note := mocks.MockNoteManager{}
+		// Act
+		result, err := actions.DeleteNote(&mocks.MockVaultOperator{}, &note, actions.DeleteParams{
+			NotePath: "target",
+			Force:    true,
+		})
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"another-note.md", "linking-note.md"}, result.LinkedFrom)
+		assert.Empty(t, note.UnlinkedNotes)
+		assert.Empty(t, note.UpdatedLinks)
+	})
+
+	t.Run("Unlinks the note before deleting it", func(t *testing.T) {
+		// Arrange
+		note := mocks.MockNoteManager{}
+		// Act
+		_, err := actions.DeleteNote(&mocks.MockVaultOperator{}, &note, actions.DeleteParams{
+			NotePath: "target",
+			Unlink:   true,
+		})
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"target"}, note.UnlinkedNotes)
+	})
+
+	t.Run("Redirects the links to another note", func(t *testing.T) {
+		// Arrange
+		note := mocks.MockNoteManager{}
+		// Act
+		_, err := actions.DeleteNote(&mocks.MockVaultOperator{}, &note, actions.DeleteParams{
+			NotePath: "target",
+			Redirect: "other",
+		})
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, [][2]string{{"target", "other"}}, note.UpdatedLinks)
+	})
+
+	t.Run("The note is kept when its links cannot be changed", func(t *testing.T) {
+		// Arrange
+		note := mocks.MockNoteManager{UpdateLinksError: errors.New("Failed to update links")}
+		// Act
+		_, err := actions.DeleteNote(&mocks.MockVaultOperator{}, &note, actions.DeleteParams{
+			NotePath: "target",
+			Unlink:   true,
+		})
+		// Assert
+		assert.Equal(t, note.UpdateLinksError, err)
+	})
+
+	t.Run("Redirect target must exist", func(t *testing.T) {
+		// Arrange
+		note := mocks.MockNoteManager{GetContentsError: errors.New("Cannot find note")}
+		// Act
+		_, err := actions.DeleteNote(&mocks.MockVaultOperator{}, &note, actions.DeleteParams{
+			NotePath: "target",
+			Redirect: "missing",
+		})
+		// Assert
+		assert.Equal(t, note.GetContentsError, err)
+	})
+
+	t.Run("Unlink and redirect cannot be combined", func(t *testing.T) {
+		// Act
+		_, err := actions.DeleteNote(&mocks.MockVaultOperator{}, &mocks.MockNoteManager{}, actions.DeleteParams{
+			NotePath: "target",
+			Unlink:   true,
+			Redirect: "other",
+		})
+		// Assert
+		assert.Error(t, err)
+	})
 }
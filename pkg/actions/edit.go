@@ -1,7 +1,6 @@
 package actions
 
 import (
-	"errors"
 	"fmt"
 	"strings"
 
@@ -17,11 +16,11 @@ type EditParams struct {
 
 func EditNote(vault obsidian.VaultManager, note obsidian.NoteManager, params EditParams) (string, error) {
 	if params.OldString == params.NewString {
-		return "", errors.New("old string and new string must be different")
+		return "", obsidian.InvalidArgument("old string and new string must be different")
 	}
 
 	if params.OldString == "" {
-		return "", errors.New("old string cannot be empty")
+		return "", obsidian.InvalidArgument("old string cannot be empty")
 	}
 
 	_, err := vault.DefaultName()
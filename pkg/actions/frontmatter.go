@@ -1,7 +1,6 @@
 package actions
 
 import (
-	"errors"
 	"fmt"
 
 	"github.com/Yakitrak/obsidian-cli/pkg/frontmatter"
@@ -50,7 +49,33 @@ func Frontmatter(vault obsidian.VaultManager, note obsidian.NoteManager, params
 		return handleDelete(note, vaultPath, params.NoteName, contents, params.Key)
 	}
 
-	return "", errors.New("no operation specified: use --print, --edit, or --delete")
+	return "", obsidian.InvalidArgument("no operation specified: use --print, --edit, or --delete")
+}
+
+// ReadFrontmatter returns the frontmatter of a note as a map, or nil when the note has none.
+func ReadFrontmatter(vault obsidian.VaultManager, note obsidian.NoteManager, noteName string) (map[string]interface{}, error) {
+	_, err := vault.DefaultName()
+	if err != nil {
+		return nil, err
+	}
+
+	vaultPath, err := vault.Path()
+	if err != nil {
+		return nil, err
+	}
+
+	contents, err := note.GetContents(vaultPath, noteName)
+	if err != nil {
+		return nil, err
+	}
+
+	if !frontmatter.HasFrontmatter(contents) {
+		return nil, nil
+	}
+	if _, _, err := frontmatter.Parse(contents); err != nil {
+		return nil, err
+	}
+	return obsidian.ParseNoteMetadata(contents).Frontmatter, nil
 }
 
 func handlePrint(contents string) (string, error) {
@@ -73,10 +98,10 @@ func handlePrint(contents string) (string, error) {
 
 func handleEdit(note obsidian.NoteManager, vaultPath, noteName, contents, key, value string) (string, error) {
 	if key == "" {
-		return "", errors.New("--key is required for edit operation")
+		return "", obsidian.InvalidArgument("--key is required for edit operation")
 	}
 	if value == "" {
-		return "", errors.New("--value is required for edit operation")
+		return "", obsidian.InvalidArgument("--value is required for edit operation")
 	}
 
 	updatedContent, err := frontmatter.SetKey(contents, key, value)
@@ -94,7 +119,7 @@ func handleEdit(note obsidian.NoteManager, vaultPath, noteName, contents, key, v
 
 func handleDelete(note obsidian.NoteManager, vaultPath, noteName, contents, key string) (string, error) {
 	if key == "" {
-		return "", errors.New("--key is required for delete operation")
+		return "", obsidian.InvalidArgument("--key is required for delete operation")
 	}
 
 	updatedContent, err := frontmatter.DeleteKey(contents, key)
@@ -235,3 +235,37 @@ func TestFrontmatter_NoOperation(t *testing.T) {
 		assert.Contains(t, err.Error(), "no operation specified")
 	})
 }
+
+func TestReadFrontmatter(t *testing.T) {
+	t.Run("Returns frontmatter as a map", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := mocks.MockNoteManager{
+			Contents: "---\ntitle: Test Note\nmeta:\n  owner: me\n---\nBody content",
+		}
+
+		fm, err := actions.ReadFrontmatter(&vault, &note, "test-note")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Test Note", fm["title"])
+		assert.Equal(t, map[string]interface{}{"owner": "me"}, fm["meta"])
+	})
+
+	t.Run("Returns nil for note without frontmatter", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := mocks.MockNoteManager{Contents: "Just body content"}
+
+		fm, err := actions.ReadFrontmatter(&vault, &note, "test-note")
+
+		assert.NoError(t, err)
+		assert.Nil(t, fm)
+	})
+
+	t.Run("Invalid frontmatter returns an error", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := mocks.MockNoteManager{Contents: "---\ntitle: [unclosed\n---\nBody"}
+
+		_, err := actions.ReadFrontmatter(&vault, &note, "test-note")
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,120 @@
+package actions
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+)
+
+const (
+	RankByPageRank = "pagerank"
+	RankByInDegree = "in-degree"
+
+	ClusterComponents  = "components"
+	ClusterCommunities = "communities"
+)
+
+type GraphPathParams struct {
+	From       string
+	To         string
+	Undirected bool
+}
+
+type GraphHubsParams struct {
+	Limit int
+	By    string
+}
+
+type GraphClustersParams struct {
+	Method  string
+	MinSize int
+}
+
+// FindGraphPath returns the shortest chain of links between two notes.
+func FindGraphPath(vault obsidian.VaultManager, note obsidian.NoteManager, params GraphPathParams) ([]string, error) {
+	graph, err := loadLinkGraph(vault, note)
+	if err != nil {
+		return nil, err
+	}
+
+	from, err := graph.Lookup(params.From)
+	if err != nil {
+		return nil, err
+	}
+	to, err := graph.Lookup(params.To)
+	if err != nil {
+		return nil, err
+	}
+
+	path := graph.ShortestPath(from, to, params.Undirected)
+	if path == nil {
+		return nil, fmt.Errorf("No link path from %s to %s", from, to)
+	}
+	return path, nil
+}
+
+// FindHubs returns the notes with the highest PageRank or in-degree, best first.
+// A Limit of 0 returns every note.
+func FindHubs(vault obsidian.VaultManager, note obsidian.NoteManager, params GraphHubsParams) ([]obsidian.NoteRank, error) {
+	if params.By != RankByPageRank && params.By != RankByInDegree {
+		return nil, obsidian.InvalidArgument("unsupported ranking %q, expected %s or %s", params.By, RankByPageRank, RankByInDegree)
+	}
+
+	graph, err := loadLinkGraph(vault, note)
+	if err != nil {
+		return nil, err
+	}
+
+	ranks := graph.Ranks()
+	sort.SliceStable(ranks, func(i, j int) bool {
+		if params.By == RankByInDegree && ranks[i].InDegree != ranks[j].InDegree {
+			return ranks[i].InDegree > ranks[j].InDegree
+		}
+		return ranks[i].PageRank > ranks[j].PageRank
+	})
+	if params.Limit > 0 && len(ranks) > params.Limit {
+		ranks = ranks[:params.Limit]
+	}
+	return ranks, nil
+}
+
+// FindClusters groups notes into connected components or link communities,
+// largest first, leaving out groups smaller than MinSize.
+func FindClusters(vault obsidian.VaultManager, note obsidian.NoteManager, params GraphClustersParams) ([][]string, error) {
+	if params.Method != ClusterComponents && params.Method != ClusterCommunities {
+		return nil, obsidian.InvalidArgument("unsupported clustering %q, expected %s or %s", params.Method, ClusterComponents, ClusterCommunities)
+	}
+
+	graph, err := loadLinkGraph(vault, note)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := graph.Components()
+	if params.Method == ClusterCommunities {
+		groups = graph.Communities()
+	}
+
+	clusters := [][]string{}
+	for _, group := range groups {
+		if len(group) >= params.MinSize {
+			clusters = append(clusters, group)
+		}
+	}
+	return clusters, nil
+}
+
+func loadLinkGraph(vault obsidian.VaultManager, note obsidian.NoteManager) (*obsidian.LinkGraph, error) {
+	_, err := vault.DefaultName()
+	if err != nil {
+		return nil, err
+	}
+
+	vaultPath, err := vault.Path()
+	if err != nil {
+		return nil, err
+	}
+
+	return note.GetLinkGraph(vaultPath)
+}
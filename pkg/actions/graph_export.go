@@ -0,0 +1,189 @@
+package actions
+
+import (
+	"bufio"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+)
+
+const (
+	FormatDOT     = "dot"
+	FormatGraphML = "graphml"
+)
+
+type GraphExportParams struct {
+	Tags        bool
+	Attachments bool
+}
+
+// ValidateGraphFormat returns an error for graph formats other than dot, graphml and json.
+func ValidateGraphFormat(format string) error {
+	switch format {
+	case FormatDOT, FormatGraphML, FormatJSON:
+		return nil
+	}
+	return obsidian.InvalidArgument("unsupported graph format %q, expected %s, %s or %s", format, FormatDOT, FormatGraphML, FormatJSON)
+}
+
+// ExportGraph returns the link graph of the vault with notes as nodes and, when
+// requested, tags and attachments as typed nodes.
+func ExportGraph(vault obsidian.VaultManager, note obsidian.NoteManager, params GraphExportParams) (*obsidian.GraphExport, error) {
+	_, err := vault.DefaultName()
+	if err != nil {
+		return nil, err
+	}
+
+	vaultPath, err := vault.Path()
+	if err != nil {
+		return nil, err
+	}
+
+	return note.ExportGraph(vaultPath, obsidian.GraphExportOptions{
+		Tags:        params.Tags,
+		Attachments: params.Attachments,
+	})
+}
+
+// WriteGraph writes the graph as a Graphviz DOT digraph, as GraphML or as JSON.
+// Frontmatter becomes node attributes; lists are joined with commas in DOT and GraphML.
+func WriteGraph(w io.Writer, format string, graph *obsidian.GraphExport) error {
+	if err := ValidateGraphFormat(format); err != nil {
+		return err
+	}
+
+	switch format {
+	case FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(graph)
+	case FormatDOT:
+		return writeDOT(w, graph)
+	default:
+		return writeGraphML(w, graph)
+	}
+}
+
+func writeDOT(w io.Writer, graph *obsidian.GraphExport) error {
+	out := bufio.NewWriter(w)
+	out.WriteString("digraph vault {\n")
+	for _, node := range graph.Nodes {
+		attributes := []string{
+			dotAttribute("label", node.Label),
+			dotAttribute("type", node.Type),
+		}
+		for _, key := range sortedAttributeKeys(node.Attributes) {
+			attributes = append(attributes, dotAttribute(key, graphAttributeValue(node.Attributes[key])))
+		}
+		fmt.Fprintf(out, "  %s [%s];\n", dotQuote(node.ID), strings.Join(attributes, ", "))
+	}
+	for _, edge := range graph.Edges {
+		fmt.Fprintf(out, "  %s -> %s [%s, weight=%d];\n", dotQuote(edge.Source), dotQuote(edge.Target), dotAttribute("type", edge.Type), edge.Weight)
+	}
+	out.WriteString("}\n")
+	return out.Flush()
+}
+
+func dotAttribute(key, value string) string {
+	return dotQuote(key) + "=" + dotQuote(value)
+}
+
+func dotQuote(s string) string {
+	s = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\r", "", "\n", `\n`).Replace(s)
+	return `"` + s + `"`
+}
+
+func writeGraphML(w io.Writer, graph *obsidian.GraphExport) error {
+	// Every frontmatter property used by any note is declared once as a node attribute
+	keys := make(map[string]string)
+	var properties []string
+	for _, node := range graph.Nodes {
+		for _, key := range sortedAttributeKeys(node.Attributes) {
+			if _, ok := keys[key]; !ok {
+				keys[key] = ""
+				properties = append(properties, key)
+			}
+		}
+	}
+	sort.Strings(properties)
+	for i, key := range properties {
+		keys[key] = fmt.Sprintf("p%d", i)
+	}
+
+	out := bufio.NewWriter(w)
+	out.WriteString(xml.Header)
+	out.WriteString(`<graphml xmlns="http://graphml.graphdrawing.org/xmlns">` + "\n")
+	out.WriteString(`  <key id="label" for="node" attr.name="label" attr.type="string"/>` + "\n")
+	out.WriteString(`  <key id="type" for="node" attr.name="type" attr.type="string"/>` + "\n")
+	for _, key := range properties {
+		fmt.Fprintf(out, "  <key id=%q for=\"node\" attr.name=\"%s\" attr.type=\"string\"/>\n", keys[key], xmlEscape(key))
+	}
+	out.WriteString(`  <key id="edgetype" for="edge" attr.name="type" attr.type="string"/>` + "\n")
+	out.WriteString(`  <key id="weight" for="edge" attr.name="weight" attr.type="int"/>` + "\n")
+	out.WriteString(`  <graph id="vault" edgedefault="directed">` + "\n")
+
+	for _, node := range graph.Nodes {
+		fmt.Fprintf(out, "    <node id=\"%s\">\n", xmlEscape(node.ID))
+		fmt.Fprintf(out, "      <data key=\"label\">%s</data>\n", xmlEscape(node.Label))
+		fmt.Fprintf(out, "      <data key=\"type\">%s</data>\n", xmlEscape(node.Type))
+		for _, key := range sortedAttributeKeys(node.Attributes) {
+			fmt.Fprintf(out, "      <data key=%q>%s</data>\n", keys[key], xmlEscape(graphAttributeValue(node.Attributes[key])))
+		}
+		out.WriteString("    </node>\n")
+	}
+	for i, edge := range graph.Edges {
+		fmt.Fprintf(out, "    <edge id=\"e%d\" source=\"%s\" target=\"%s\">\n", i, xmlEscape(edge.Source), xmlEscape(edge.Target))
+		fmt.Fprintf(out, "      <data key=\"edgetype\">%s</data>\n", xmlEscape(edge.Type))
+		fmt.Fprintf(out, "      <data key=\"weight\">%d</data>\n", edge.Weight)
+		out.WriteString("    </edge>\n")
+	}
+
+	out.WriteString("  </graph>\n</graphml>\n")
+	return out.Flush()
+}
+
+func xmlEscape(s string) string {
+	var sb strings.Builder
+	_ = xml.EscapeText(&sb, []byte(s))
+	return sb.String()
+}
+
+// sortedAttributeKeys returns the frontmatter properties written as node attributes.
+// Properties named like the label and type attributes are left out.
+func sortedAttributeKeys(attributes map[string]interface{}) []string {
+	keys := make([]string, 0, len(attributes))
+	for key := range attributes {
+		if key != "label" && key != "type" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// graphAttributeValue flattens a frontmatter value for formats with string attributes.
+func graphAttributeValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case []interface{}:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			items = append(items, graphAttributeValue(item))
+		}
+		return strings.Join(items, ", ")
+	case map[string]interface{}:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(encoded)
+	default:
+		return fmt.Sprint(v)
+	}
+}
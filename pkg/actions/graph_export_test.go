@@ -0,0 +1,122 @@
+package actions_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+
+	"github.com/Yakitrak/obsidian-cli/mocks"
+	"github.com/Yakitrak/obsidian-cli/pkg/actions"
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportGraph(t *testing.T) {
+	t.Run("Passes the node options to the note manager", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := mocks.MockNoteManager{}
+		// Act
+		_, err := actions.ExportGraph(&vault, &note, actions.GraphExportParams{Tags: true})
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, obsidian.GraphExportOptions{Tags: true}, note.GraphExportOptions)
+	})
+
+	t.Run("vault.Path returns an error", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{PathError: obsidian.ErrVaultNotFound}
+		note := mocks.MockNoteManager{}
+		// Act
+		_, err := actions.ExportGraph(&vault, &note, actions.GraphExportParams{})
+		// Assert
+		assert.ErrorIs(t, err, obsidian.ErrVaultNotFound)
+	})
+}
+
+func TestWriteGraph(t *testing.T) {
+	graph := &obsidian.GraphExport{
+		Nodes: []obsidian.GraphNode{
+			{ID: "notes/a.md", Type: obsidian.NoteNode, Label: `a "quoted"`, Attributes: map[string]interface{}{"tags": []interface{}{"x", "y"}, "type": "ignored"}},
+			{ID: "b.md", Type: obsidian.NoteNode, Label: "b & c"},
+			{ID: "#x", Type: obsidian.TagNode, Label: "#x"},
+		},
+		Edges: []obsidian.GraphEdge{
+			{Source: "notes/a.md", Target: "b.md", Type: obsidian.LinkEdge, Weight: 2},
+			{Source: "notes/a.md", Target: "#x", Type: obsidian.TagEdge, Weight: 1},
+		},
+	}
+
+	t.Run("dot writes a digraph", func(t *testing.T) {
+		// Arrange
+		var out bytes.Buffer
+
+		// Act
+		err := actions.WriteGraph(&out, actions.FormatDOT, graph)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, `digraph vault {
+  "notes/a.md" ["label"="a \"quoted\"", "type"="note", "tags"="x, y"];
+  "b.md" ["label"="b & c", "type"="note"];
+  "#x" ["label"="#x", "type"="tag"];
+  "notes/a.md" -> "b.md" ["type"="link", weight=2];
+  "notes/a.md" -> "#x" ["type"="tag", weight=1];
+}
+`, out.String())
+	})
+
+	t.Run("graphml is well-formed and declares frontmatter keys", func(t *testing.T) {
+		// Arrange
+		var out bytes.Buffer
+
+		// Act
+		err := actions.WriteGraph(&out, actions.FormatGraphML, graph)
+
+		// Assert
+		assert.NoError(t, err)
+		var decoded struct {
+			Keys []struct {
+				Name string `xml:"attr.name,attr"`
+			} `xml:"key"`
+			Graph struct {
+				Nodes []struct {
+					ID string `xml:"id,attr"`
+				} `xml:"node"`
+				Edges []struct {
+					Source string `xml:"source,attr"`
+				} `xml:"edge"`
+			} `xml:"graph"`
+		}
+		assert.NoError(t, xml.Unmarshal(out.Bytes(), &decoded))
+		assert.Len(t, decoded.Keys, 5)
+		assert.Equal(t, "tags", decoded.Keys[2].Name)
+		assert.Len(t, decoded.Graph.Nodes, 3)
+		assert.Len(t, decoded.Graph.Edges, 2)
+		assert.Contains(t, out.String(), "b &amp; c")
+	})
+
+	t.Run("json writes nodes and edges", func(t *testing.T) {
+		// Arrange
+		var out bytes.Buffer
+
+		// Act
+		err := actions.WriteGraph(&out, actions.FormatJSON, graph)
+
+		// Assert
+		assert.NoError(t, err)
+		var decoded obsidian.GraphExport
+		assert.NoError(t, json.Unmarshal(out.Bytes(), &decoded))
+		assert.Equal(t, graph.Edges, decoded.Edges)
+		assert.Len(t, decoded.Nodes, 3)
+	})
+
+	t.Run("Unsupported format", func(t *testing.T) {
+		// Act
+		err := actions.WriteGraph(&bytes.Buffer{}, "png", graph)
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
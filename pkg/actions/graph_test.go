@@ -0,0 +1,108 @@
+package actions_test
+
+import (
+	"testing"
+
+	"github.com/Yakitrak/obsidian-cli/mocks"
+	"github.com/Yakitrak/obsidian-cli/pkg/actions"
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/stretchr/testify/assert"
+)
+
+func testLinkGraph() *obsidian.LinkGraph {
+	return &obsidian.LinkGraph{
+		Notes: []string{"a.md", "b.md", "c.md", "d.md"},
+		Outgoing: map[string][]string{
+			"a.md": {"b.md"},
+			"b.md": {"c.md"},
+			"d.md": {"c.md"},
+		},
+		Incoming: map[string][]string{
+			"b.md": {"a.md"},
+			"c.md": {"b.md", "d.md"},
+		},
+	}
+}
+
+func TestFindGraphPath(t *testing.T) {
+	t.Run("Returns the chain of links", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := mocks.MockNoteManager{LinkGraph: testLinkGraph()}
+		// Act
+		path, err := actions.FindGraphPath(&vault, &note, actions.GraphPathParams{From: "a", To: "c"})
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a.md", "b.md", "c.md"}, path)
+	})
+
+	t.Run("No path between the notes", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := mocks.MockNoteManager{LinkGraph: testLinkGraph()}
+		// Act
+		_, err := actions.FindGraphPath(&vault, &note, actions.GraphPathParams{From: "a", To: "d"})
+		// Assert
+		assert.EqualError(t, err, "No link path from a.md to d.md")
+	})
+
+	t.Run("Unknown note", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := mocks.MockNoteManager{LinkGraph: testLinkGraph()}
+		// Act
+		_, err := actions.FindGraphPath(&vault, &note, actions.GraphPathParams{From: "a", To: "missing"})
+		// Assert
+		assert.ErrorIs(t, err, obsidian.ErrNoteNotFound)
+	})
+}
+
+func TestFindHubs(t *testing.T) {
+	t.Run("Ranks by in-degree with a limit", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := mocks.MockNoteManager{LinkGraph: testLinkGraph()}
+		// Act
+		hubs, err := actions.FindHubs(&vault, &note, actions.GraphHubsParams{Limit: 2, By: actions.RankByInDegree})
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, hubs, 2)
+		assert.Equal(t, "c.md", hubs[0].Note)
+		assert.Equal(t, "b.md", hubs[1].Note)
+	})
+
+	t.Run("Unsupported ranking", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := mocks.MockNoteManager{}
+		// Act
+		_, err := actions.FindHubs(&vault, &note, actions.GraphHubsParams{By: "size"})
+		// Assert
+		assert.Error(t, err)
+	})
+}
+
+func TestFindClusters(t *testing.T) {
+	t.Run("Leaves out small clusters", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		graph := testLinkGraph()
+		graph.Notes = append(graph.Notes, "lonely.md")
+		note := mocks.MockNoteManager{LinkGraph: graph}
+		// Act
+		clusters, err := actions.FindClusters(&vault, &note, actions.GraphClustersParams{Method: actions.ClusterComponents, MinSize: 2})
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, [][]string{{"a.md", "b.md", "c.md", "d.md"}}, clusters)
+	})
+
+	t.Run("Unsupported method", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := mocks.MockNoteManager{}
+		// Act
+		_, err := actions.FindClusters(&vault, &note, actions.GraphClustersParams{Method: "kmeans"})
+		// Assert
+		assert.Error(t, err)
+	})
+}
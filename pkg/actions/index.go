@@ -0,0 +1,33 @@
+package actions
+
+import (
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+)
+
+type IndexParams struct {
+	Rebuild bool
+}
+
+// Index reports the state of the cached note index, rebuilding it first when requested.
+func Index(vault obsidian.VaultManager, params IndexParams) (obsidian.IndexStatus, error) {
+	_, err := vault.DefaultName()
+	if err != nil {
+		return obsidian.IndexStatus{}, err
+	}
+
+	vaultPath, err := vault.Path()
+	if err != nil {
+		return obsidian.IndexStatus{}, err
+	}
+
+	if params.Rebuild {
+		if _, err := obsidian.RebuildIndex(vaultPath); err != nil {
+			return obsidian.IndexStatus{}, err
+		}
+		if _, err := obsidian.RebuildSearchIndex(vaultPath); err != nil {
+			return obsidian.IndexStatus{}, err
+		}
+	}
+
+	return obsidian.GetIndexStatus(vaultPath)
+}
@@ -0,0 +1,52 @@
+package actions_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Yakitrak/obsidian-cli/mocks"
+	"github.com/Yakitrak/obsidian-cli/pkg/actions"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIndex(t *testing.T) {
+	t.Run("Status before and after rebuild", func(t *testing.T) {
+		// Arrange
+		vaultDir := t.TempDir()
+		assert.NoError(t, os.WriteFile(filepath.Join(vaultDir, "note.md"), []byte("content"), 0644))
+		vault := mocks.MockVaultOperator{Name: "myVault", VaultPath: vaultDir}
+
+		// Act
+		before, err := actions.Index(&vault, actions.IndexParams{})
+		assert.NoError(t, err)
+		after, err := actions.Index(&vault, actions.IndexParams{Rebuild: true})
+		assert.NoError(t, err)
+
+		// Assert
+		assert.False(t, before.Exists)
+		assert.Equal(t, 1, before.Added)
+		assert.True(t, after.Exists)
+		assert.Equal(t, 1, after.Notes)
+		assert.Equal(t, 0, after.Added)
+	})
+
+	t.Run("vault.DefaultName returns an error", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{DefaultNameErr: errors.New("Failed to get vault name")}
+		// Act
+		_, err := actions.Index(&vault, actions.IndexParams{})
+		// Assert
+		assert.Equal(t, vault.DefaultNameErr, err)
+	})
+
+	t.Run("vault.Path returns an error", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{PathError: errors.New("Failed to get vault path")}
+		// Act
+		_, err := actions.Index(&vault, actions.IndexParams{Rebuild: true})
+		// Assert
+		assert.Equal(t, vault.PathError, err)
+	})
+}
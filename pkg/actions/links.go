@@ -0,0 +1,24 @@
+package actions
+
+import (
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+)
+
+type LinksParams struct {
+	NoteName string
+}
+
+// ListLinks returns the outgoing links of a note resolved against the vault.
+func ListLinks(vault obsidian.VaultManager, note obsidian.NoteManager, params LinksParams) ([]obsidian.ResolvedLink, error) {
+	_, err := vault.DefaultName()
+	if err != nil {
+		return nil, err
+	}
+
+	vaultPath, err := vault.Path()
+	if err != nil {
+		return nil, err
+	}
+
+	return note.OutgoingLinks(vaultPath, params.NoteName)
+}
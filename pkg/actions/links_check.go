@@ -0,0 +1,76 @@
+package actions
+
+import (
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+)
+
+type LinksCheckParams struct {
+	Fix bool
+}
+
+// LinkFix records that links to a missing note were rewritten to point at another note.
+type LinkFix struct {
+	Target string `json:"target"`
+	Path   string `json:"path"`
+}
+
+// LinksCheckResult lists the links that are still broken and, with Fix, those that were repaired.
+type LinksCheckResult struct {
+	Broken []obsidian.BrokenLink `json:"broken"`
+	Fixed  []LinkFix             `json:"fixed,omitempty"`
+}
+
+// CheckLinks reports every broken link in the vault. With Fix, links to a missing
+// note are rewritten to the note with the closest name when there is exactly one
+// closest match, the same way links are updated when a note is moved. The fix is
+// given by its vault path, so links by name are rewritten to its path when other
+// notes share its name.
+func CheckLinks(vault obsidian.VaultManager, note obsidian.NoteManager, params LinksCheckParams) (LinksCheckResult, error) {
+	_, err := vault.DefaultName()
+	if err != nil {
+		return LinksCheckResult{}, err
+	}
+
+	vaultPath, err := vault.Path()
+	if err != nil {
+		return LinksCheckResult{}, err
+	}
+
+	broken, err := note.FindBrokenLinks(vaultPath)
+	if err != nil {
+		return LinksCheckResult{}, err
+	}
+	if !params.Fix {
+		return LinksCheckResult{Broken: broken}, nil
+	}
+
+	// Either every fix is applied or none of them
+	var result LinksCheckResult
+	err = note.Transact(func() error {
+		fixed := make(map[string]bool)
+		for _, link := range broken {
+			if link.Fix == "" || fixed[link.Target] {
+				continue
+			}
+			if err := note.UpdateLinks(vaultPath, link.Target, link.Fix); err != nil {
+				return err
+			}
+			fixed[link.Target] = true
+			result.Fixed = append(result.Fixed, LinkFix{Target: link.Target, Path: link.Fix})
+		}
+		return nil
+	})
+	if err != nil {
+		return LinksCheckResult{}, err
+	}
+
+	if len(result.Fixed) == 0 {
+		result.Broken = broken
+		return result, nil
+	}
+	result.Broken, err = note.FindBrokenLinks(vaultPath)
+	if err != nil {
+		return LinksCheckResult{}, err
+	}
+	return result, nil
+}
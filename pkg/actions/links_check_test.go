@@ -0,0 +1,64 @@
+package actions_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Yakitrak/obsidian-cli/mocks"
+	"github.com/Yakitrak/obsidian-cli/pkg/actions"
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckLinks(t *testing.T) {
+	broken := []obsidian.BrokenLink{
+		{Source: "a.md", Link: obsidian.Link{Target: "Projct"}, Reason: obsidian.MissingNote, Suggestions: []string{"Project.md"}, Fix: "Project.md"},
+		{Source: "b.md", Link: obsidian.Link{Target: "Projct"}, Reason: obsidian.MissingNote, Suggestions: []string{"Project.md"}, Fix: "Project.md"},
+		{Source: "b.md", Link: obsidian.Link{Target: "Project", Heading: "Nope"}, Reason: obsidian.MissingHeading},
+	}
+
+	t.Run("Reports broken links without changing notes", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := mocks.MockNoteManager{BrokenLinksResult: broken}
+		// Act
+		result, err := actions.CheckLinks(&vault, &note, actions.LinksCheckParams{})
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, broken, result.Broken)
+		assert.Empty(t, result.Fixed)
+		assert.Empty(t, note.UpdatedLinks)
+	})
+
+	t.Run("Fix rewrites each missing target once", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := mocks.MockNoteManager{BrokenLinksResult: broken}
+		// Act
+		result, err := actions.CheckLinks(&vault, &note, actions.LinksCheckParams{Fix: true})
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, [][2]string{{"Projct", "Project.md"}}, note.UpdatedLinks)
+		assert.Equal(t, []actions.LinkFix{{Target: "Projct", Path: "Project.md"}}, result.Fixed)
+	})
+
+	t.Run("UpdateLinks returns an error", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := mocks.MockNoteManager{BrokenLinksResult: broken, UpdateLinksError: errors.New("write failed")}
+		// Act
+		_, err := actions.CheckLinks(&vault, &note, actions.LinksCheckParams{Fix: true})
+		// Assert
+		assert.Equal(t, note.UpdateLinksError, err)
+	})
+
+	t.Run("FindBrokenLinks returns an error", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := mocks.MockNoteManager{BrokenLinksErr: obsidian.ErrVaultAccess}
+		// Act
+		_, err := actions.CheckLinks(&vault, &note, actions.LinksCheckParams{})
+		// Assert
+		assert.ErrorIs(t, err, obsidian.ErrVaultAccess)
+	})
+}
@@ -0,0 +1,47 @@
+package actions_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Yakitrak/obsidian-cli/mocks"
+	"github.com/Yakitrak/obsidian-cli/pkg/actions"
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListLinks(t *testing.T) {
+	t.Run("Returns the resolved links of the note", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		links := []obsidian.ResolvedLink{
+			{Link: obsidian.Link{Kind: obsidian.WikiLinkKind, Target: "other", Line: 1}, Path: "other.md", Exists: true},
+		}
+		note := mocks.MockNoteManager{OutgoingLinksResult: links}
+		// Act
+		result, err := actions.ListLinks(&vault, &note, actions.LinksParams{NoteName: "note"})
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, links, result)
+	})
+
+	t.Run("vault.DefaultName returns an error", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{DefaultNameErr: errors.New("Failed to get vault name")}
+		note := mocks.MockNoteManager{}
+		// Act
+		_, err := actions.ListLinks(&vault, &note, actions.LinksParams{NoteName: "note"})
+		// Assert
+		assert.Equal(t, vault.DefaultNameErr, err)
+	})
+
+	t.Run("OutgoingLinks returns an error", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{}
+		note := mocks.MockNoteManager{OutgoingLinksErr: obsidian.ErrNoteNotFound}
+		// Act
+		_, err := actions.ListLinks(&vault, &note, actions.LinksParams{NoteName: "note"})
+		// Assert
+		assert.ErrorIs(t, err, obsidian.ErrNoteNotFound)
+	})
+}
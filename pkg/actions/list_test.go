@@ -35,6 +35,10 @@ func (v *vaultStub) Path() (string, error) {
 	return v.path, nil
 }
 
+func (v *vaultStub) ID() (string, error) {
+	return "", nil
+}
+
 func (v *vaultStub) DailyNotePattern() (string, error) {
 	return "", nil
 }
@@ -0,0 +1,12 @@
+package actions_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/Yakitrak/obsidian-cli/mocks"
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(mocks.RunWithTempConfig(m))
+}
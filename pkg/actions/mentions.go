@@ -0,0 +1,78 @@
+package actions
+
+import (
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+)
+
+type MentionsParams struct {
+	NoteName string
+	Unlinked bool
+	Link     bool
+	// Select limits Link to the mentions at these 1-based positions of the list.
+	Select []int
+}
+
+// MentionsResult lists the mentions of a note and, with Link, those turned into links.
+type MentionsResult struct {
+	Mentions []obsidian.Mention `json:"mentions"`
+	Linked   []obsidian.Mention `json:"linked,omitempty"`
+}
+
+// FindMentions returns the notes that link to a note or, with Unlinked, that mention
+// its name or aliases in plain text. With Link, the unlinked mentions (or the
+// selected ones) are turned into wikilinks in place.
+func FindMentions(vault obsidian.VaultManager, note obsidian.NoteManager, params MentionsParams) (MentionsResult, error) {
+	if params.Link && !params.Unlinked {
+		return MentionsResult{}, obsidian.InvalidArgument("--link requires --unlinked")
+	}
+
+	_, err := vault.DefaultName()
+	if err != nil {
+		return MentionsResult{}, err
+	}
+
+	vaultPath, err := vault.Path()
+	if err != nil {
+		return MentionsResult{}, err
+	}
+
+	if !params.Unlinked {
+		backlinks, err := note.FindBacklinks(vaultPath, params.NoteName)
+		if err != nil {
+			return MentionsResult{}, err
+		}
+		mentions := make([]obsidian.Mention, 0, len(backlinks))
+		for _, backlink := range backlinks {
+			mentions = append(mentions, obsidian.Mention{Source: backlink.FilePath, Line: backlink.LineNumber, Context: backlink.MatchLine})
+		}
+		return MentionsResult{Mentions: mentions}, nil
+	}
+
+	mentions, err := note.FindUnlinkedMentions(vaultPath, params.NoteName)
+	if err != nil {
+		return MentionsResult{}, err
+	}
+	if !params.Link {
+		return MentionsResult{Mentions: mentions}, nil
+	}
+
+	selected := mentions
+	if len(params.Select) > 0 {
+		selected = nil
+		for _, position := range params.Select {
+			if position < 1 || position > len(mentions) {
+				return MentionsResult{}, obsidian.InvalidArgument("no mention %d, expected 1 to %d", position, len(mentions))
+			}
+			selected = append(selected, mentions[position-1])
+		}
+	}
+	if len(selected) == 0 {
+		return MentionsResult{Mentions: mentions}, nil
+	}
+
+	linked, err := note.LinkMentions(vaultPath, params.NoteName, selected)
+	if err != nil {
+		return MentionsResult{}, err
+	}
+	return MentionsResult{Mentions: mentions, Linked: linked}, nil
+}
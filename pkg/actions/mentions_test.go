@@ -0,0 +1,107 @@
+package actions_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Yakitrak/obsidian-cli/mocks"
+	"github.com/Yakitrak/obsidian-cli/pkg/actions"
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindMentions(t *testing.T) {
+	unlinked := []obsidian.Mention{
+		{Source: "a.md", Line: 1, Column: 1, Text: "note"},
+		{Source: "b.md", Line: 2, Column: 5, Text: "alias"},
+	}
+
+	t.Run("Lists linked mentions by default", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := mocks.MockNoteManager{FindBacklinksResult: []obsidian.NoteMatch{
+			{FilePath: "a.md", LineNumber: 3, MatchLine: "see [[note]]"},
+		}}
+		// Act
+		result, err := actions.FindMentions(&vault, &note, actions.MentionsParams{NoteName: "note"})
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []obsidian.Mention{{Source: "a.md", Line: 3, Context: "see [[note]]"}}, result.Mentions)
+	})
+
+	t.Run("Lists unlinked mentions without changing notes", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := mocks.MockNoteManager{MentionsResult: unlinked}
+		// Act
+		result, err := actions.FindMentions(&vault, &note, actions.MentionsParams{NoteName: "note", Unlinked: true})
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, unlinked, result.Mentions)
+		assert.Empty(t, note.LinkedMentions)
+	})
+
+	t.Run("Links every unlinked mention", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := mocks.MockNoteManager{MentionsResult: unlinked}
+		// Act
+		result, err := actions.FindMentions(&vault, &note, actions.MentionsParams{NoteName: "note", Unlinked: true, Link: true})
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, unlinked, note.LinkedMentions)
+		assert.Equal(t, unlinked, result.Linked)
+	})
+
+	t.Run("Links only the selected mentions", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := mocks.MockNoteManager{MentionsResult: unlinked}
+		// Act
+		_, err := actions.FindMentions(&vault, &note, actions.MentionsParams{NoteName: "note", Unlinked: true, Link: true, Select: []int{2}})
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, unlinked[1:], note.LinkedMentions)
+	})
+
+	t.Run("Selecting a mention that does not exist", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := mocks.MockNoteManager{MentionsResult: unlinked}
+		// Act
+		_, err := actions.FindMentions(&vault, &note, actions.MentionsParams{NoteName: "note", Unlinked: true, Link: true, Select: []int{3}})
+		// Assert
+		assert.EqualError(t, err, "no mention 3, expected 1 to 2")
+		assert.Empty(t, note.LinkedMentions)
+	})
+
+	t.Run("Link without unlinked", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := mocks.MockNoteManager{}
+		// Act
+		_, err := actions.FindMentions(&vault, &note, actions.MentionsParams{NoteName: "note", Link: true})
+		// Assert
+		assert.Error(t, err)
+	})
+
+	t.Run("vault.DefaultName returns an error", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{DefaultNameErr: errors.New("Failed to get vault name")}
+		note := mocks.MockNoteManager{}
+		// Act
+		_, err := actions.FindMentions(&vault, &note, actions.MentionsParams{NoteName: "note"})
+		// Assert
+		assert.Equal(t, vault.DefaultNameErr, err)
+	})
+
+	t.Run("FindUnlinkedMentions returns an error", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{}
+		note := mocks.MockNoteManager{MentionsErr: obsidian.ErrNoteNotFound}
+		// Act
+		_, err := actions.FindMentions(&vault, &note, actions.MentionsParams{NoteName: "note", Unlinked: true})
+		// Assert
+		assert.ErrorIs(t, err, obsidian.ErrNoteNotFound)
+	})
+}
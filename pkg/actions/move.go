@@ -1,6 +1,8 @@
 package actions
 
 import (
+	"path/filepath"
+
 	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
 )
 
@@ -11,55 +13,129 @@ type MoveParams struct {
 	UseEditor       bool
 }
 
-func MoveNote(vault obsidian.VaultManager, note obsidian.NoteManager, uri obsidian.UriManager, params MoveParams) error {
-	vaultName, err := vault.DefaultName()
+type MoveFolderParams struct {
+	CurrentFolder string
+	NewFolder     string
+}
+
+// MoveResult holds the file paths of a moved note.
+type MoveResult struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+func MoveNote(vault obsidian.VaultManager, note obsidian.NoteManager, uri obsidian.UriManager, params MoveParams) (MoveResult, error) {
+	_, err := vault.DefaultName()
 	if err != nil {
-		return err
+		return MoveResult{}, err
 	}
 	vaultPath, err := vault.Path()
 	if err != nil {
-		return err
+		return MoveResult{}, err
 	}
 
 	// Validate paths stay within vault directory
 	currentPath, err := obsidian.ValidatePath(vaultPath, params.CurrentNoteName)
 	if err != nil {
-		return err
+		return MoveResult{}, err
 	}
 	newPath, err := obsidian.ValidatePath(vaultPath, params.NewNoteName)
 	if err != nil {
-		return err
+		return MoveResult{}, err
 	}
 
-	err = note.Move(currentPath, newPath)
+	// The note is only moved if the links to it can be updated as well
+	err = note.Transact(func() error {
+		if err := note.Move(currentPath, newPath); err != nil {
+			return err
+		}
+		return note.UpdateLinks(vaultPath, params.CurrentNoteName, params.NewNoteName)
+	})
 	if err != nil {
-		return err
+		return MoveResult{}, err
 	}
 
-	err = note.UpdateLinks(vaultPath, params.CurrentNoteName, params.NewNoteName)
-	if err != nil {
-		return err
-	}
+	result := MoveResult{From: obsidian.AddMdSuffix(currentPath), To: obsidian.AddMdSuffix(newPath)}
 
 	if params.ShouldOpen {
 		if params.UseEditor {
 			filePathWithExt, err := obsidian.ValidatePath(vaultPath, obsidian.AddMdSuffix(params.NewNoteName))
 			if err != nil {
-				return err
+				return MoveResult{}, err
+			}
+			if err := obsidian.OpenInEditor(filePathWithExt); err != nil {
+				return MoveResult{}, err
 			}
-			return obsidian.OpenInEditor(filePathWithExt)
+			return result, nil
 		}
 
+		vaultID, err := vault.ID()
+		if err != nil {
+			return MoveResult{}, err
+		}
 		obsidianUri := uri.Construct(ObsOpenUrl, map[string]string{
 			"file":  params.NewNoteName,
-			"vault": vaultName,
+			"vault": vaultID,
 		})
+		if err := uri.Execute(obsidianUri); err != nil {
+			return MoveResult{}, err
+		}
+	}
 
-		err := uri.Execute(obsidianUri)
-		if err != nil {
+	return result, nil
+}
+
+// MoveFolder renames a folder of the vault, creating parent folders as needed, and
+// updates the links that point into it by vault path in every note.
+func MoveFolder(vault obsidian.VaultManager, note obsidian.NoteManager, params MoveFolderParams) (MoveResult, error) {
+	_, err := vault.DefaultName()
+	if err != nil {
+		return MoveResult{}, err
+	}
+	vaultPath, err := vault.Path()
+	if err != nil {
+		return MoveResult{}, err
+	}
+
+	// Validate paths stay within vault directory
+	currentPath, err := obsidian.ValidatePath(vaultPath, params.CurrentFolder)
+	if err != nil {
+		return MoveResult{}, err
+	}
+	newPath, err := obsidian.ValidatePath(vaultPath, params.NewFolder)
+	if err != nil {
+		return MoveResult{}, err
+	}
+	currentFolder, err := vaultRelativePath(vaultPath, currentPath)
+	if err != nil {
+		return MoveResult{}, err
+	}
+	newFolder, err := vaultRelativePath(vaultPath, newPath)
+	if err != nil {
+		return MoveResult{}, err
+	}
+	if currentFolder == "." || newFolder == "." {
+		return MoveResult{}, obsidian.InvalidArgument("Cannot move the vault folder itself")
+	}
+
+	err = note.Transact(func() error {
+		if err := note.MoveFolder(currentPath, newPath); err != nil {
 			return err
 		}
+		return note.UpdateFolderLinks(vaultPath, currentFolder, newFolder)
+	})
+	if err != nil {
+		return MoveResult{}, err
 	}
 
-	return nil
+	return MoveResult{From: currentPath, To: newPath}, nil
+}
+
+// vaultRelativePath returns the path of a validated absolute path inside the vault.
+func vaultRelativePath(vaultPath, absPath string) (string, error) {
+	absVault, err := filepath.Abs(vaultPath)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Rel(absVault, absPath)
 }
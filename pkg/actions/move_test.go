@@ -3,10 +3,12 @@ package actions_test
 import (
 	"errors"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/Yakitrak/obsidian-cli/mocks"
 	"github.com/Yakitrak/obsidian-cli/pkg/actions"
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -17,13 +19,15 @@ func TestMoveNote(t *testing.T) {
 		uri := mocks.MockUriManager{}
 		note := mocks.MockNoteManager{}
 		// Act
-		err := actions.MoveNote(&vault, &note, &uri, actions.MoveParams{
+		result, err := actions.MoveNote(&vault, &note, &uri, actions.MoveParams{
 			CurrentNoteName: "string",
 			NewNoteName:     "string",
 			ShouldOpen:      true,
 		})
 		// Assert
 		assert.NoError(t, err, "Expected no error")
+		assert.Equal(t, "string.md", filepath.Base(result.From))
+		assert.Equal(t, "string.md", filepath.Base(result.To))
 	})
 
 	t.Run("vault.DefaultName returns an error", func(t *testing.T) {
@@ -32,7 +36,7 @@ func TestMoveNote(t *testing.T) {
 			DefaultNameErr: errors.New("Failed to get vault name"),
 		}
 		// Act
-		err := actions.MoveNote(&vault, &mocks.MockNoteManager{}, &mocks.MockUriManager{}, actions.MoveParams{
+		_, err := actions.MoveNote(&vault, &mocks.MockNoteManager{}, &mocks.MockUriManager{}, actions.MoveParams{
 			CurrentNoteName: "string",
 			NewNoteName:     "string",
 			ShouldOpen:      true,
@@ -47,7 +51,7 @@ func TestMoveNote(t *testing.T) {
 			PathError: errors.New("Failed to get vault path"),
 		}
 		// Act
-		err := actions.MoveNote(vaultOp, &mocks.MockNoteManager{}, &mocks.MockUriManager{}, actions.MoveParams{
+		_, err := actions.MoveNote(vaultOp, &mocks.MockNoteManager{}, &mocks.MockUriManager{}, actions.MoveParams{
 			CurrentNoteName: "string",
 			NewNoteName:     "string",
 			ShouldOpen:      false,
@@ -62,7 +66,7 @@ func TestMoveNote(t *testing.T) {
 			MoveErr: errors.New("Failed to execute URI"),
 		}
 		// Act
-		err := actions.MoveNote(&mocks.MockVaultOperator{}, &note, &mocks.MockUriManager{}, actions.MoveParams{
+		_, err := actions.MoveNote(&mocks.MockVaultOperator{}, &note, &mocks.MockUriManager{}, actions.MoveParams{
 			CurrentNoteName: "string",
 			NewNoteName:     "string",
 			ShouldOpen:      false,
@@ -77,7 +81,7 @@ func TestMoveNote(t *testing.T) {
 			UpdateLinksError: errors.New("Failed to execute URI"),
 		}
 		// Act
-		err := actions.MoveNote(&mocks.MockVaultOperator{}, &note, &mocks.MockUriManager{}, actions.MoveParams{
+		_, err := actions.MoveNote(&mocks.MockVaultOperator{}, &note, &mocks.MockUriManager{}, actions.MoveParams{
 			CurrentNoteName: "string",
 			NewNoteName:     "string",
 			ShouldOpen:      false,
@@ -92,7 +96,7 @@ func TestMoveNote(t *testing.T) {
 			ExecuteErr: errors.New("Failed to execute URI"),
 		}
 		// Act
-		err := actions.MoveNote(&mocks.MockVaultOperator{}, &mocks.MockNoteManager{}, uriManager, actions.MoveParams{
+		_, err := actions.MoveNote(&mocks.MockVaultOperator{}, &mocks.MockNoteManager{}, uriManager, actions.MoveParams{
 			CurrentNoteName: "string",
 			NewNoteName:     "string",
 			ShouldOpen:      true,
@@ -114,7 +118,7 @@ func TestMoveNote(t *testing.T) {
 		os.Setenv("EDITOR", "true")
 
 		// Act
-		err := actions.MoveNote(&vault, &note, &uri, actions.MoveParams{
+		_, err := actions.MoveNote(&vault, &note, &uri, actions.MoveParams{
 			CurrentNoteName: "old.md",
 			NewNoteName:     "new.md",
 			ShouldOpen:      true,
@@ -137,7 +141,7 @@ func TestMoveNote(t *testing.T) {
 		os.Setenv("EDITOR", "false")
 
 		// Act
-		err := actions.MoveNote(&vault, &note, &uri, actions.MoveParams{
+		_, err := actions.MoveNote(&vault, &note, &uri, actions.MoveParams{
 			CurrentNoteName: "old.md",
 			NewNoteName:     "new.md",
 			ShouldOpen:      true,
@@ -155,7 +159,7 @@ func TestMoveNote(t *testing.T) {
 		note := mocks.MockNoteManager{}
 
 		// Act - UseEditor is true but ShouldOpen is false
-		err := actions.MoveNote(&vault, &note, &uri, actions.MoveParams{
+		_, err := actions.MoveNote(&vault, &note, &uri, actions.MoveParams{
 			CurrentNoteName: "old.md",
 			NewNoteName:     "new.md",
 			ShouldOpen:      false,
@@ -166,3 +170,69 @@ func TestMoveNote(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+func TestMoveFolder(t *testing.T) {
+	t.Run("Successful move folder", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := mocks.MockNoteManager{}
+		// Act
+		result, err := actions.MoveFolder(&vault, &note, actions.MoveFolderParams{
+			CurrentFolder: "old/dir",
+			NewFolder:     "new/dir/",
+		})
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, [][2]string{{filepath.Join("old", "dir"), filepath.Join("new", "dir")}}, note.MovedFolders)
+		assert.True(t, filepath.IsAbs(result.To))
+		assert.Equal(t, "dir", filepath.Base(result.To))
+	})
+
+	t.Run("vault.DefaultName returns an error", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{DefaultNameErr: errors.New("Failed to get vault name")}
+		// Act
+		_, err := actions.MoveFolder(&vault, &mocks.MockNoteManager{}, actions.MoveFolderParams{CurrentFolder: "old", NewFolder: "new"})
+		// Assert
+		assert.Equal(t, vault.DefaultNameErr, err)
+	})
+
+	t.Run("Folder outside the vault", func(t *testing.T) {
+		// Arrange
+		note := mocks.MockNoteManager{}
+		// Act
+		_, err := actions.MoveFolder(&mocks.MockVaultOperator{}, &note, actions.MoveFolderParams{CurrentFolder: "old", NewFolder: "../new"})
+		// Assert
+		assert.ErrorIs(t, err, obsidian.ErrPathTraversal)
+		assert.Empty(t, note.MovedFolders)
+	})
+
+	t.Run("Moving the vault folder itself", func(t *testing.T) {
+		// Arrange
+		note := mocks.MockNoteManager{}
+		// Act
+		_, err := actions.MoveFolder(&mocks.MockVaultOperator{}, &note, actions.MoveFolderParams{CurrentFolder: ".", NewFolder: "new"})
+		// Assert
+		assert.Error(t, err)
+		assert.Empty(t, note.MovedFolders)
+	})
+
+	t.Run("note.MoveFolder returns an error", func(t *testing.T) {
+		// Arrange
+		note := mocks.MockNoteManager{MoveErr: errors.New("Cannot find folder")}
+		// Act
+		_, err := actions.MoveFolder(&mocks.MockVaultOperator{}, &note, actions.MoveFolderParams{CurrentFolder: "old", NewFolder: "new"})
+		// Assert
+		assert.Equal(t, note.MoveErr, err)
+		assert.Empty(t, note.MovedFolders)
+	})
+
+	t.Run("note.UpdateFolderLinks returns an error", func(t *testing.T) {
+		// Arrange
+		note := mocks.MockNoteManager{UpdateLinksError: errors.New("Failed to update links")}
+		// Act
+		_, err := actions.MoveFolder(&mocks.MockVaultOperator{}, &note, actions.MoveFolderParams{CurrentFolder: "old", NewFolder: "new"})
+		// Assert
+		assert.Equal(t, note.UpdateLinksError, err)
+	})
+}
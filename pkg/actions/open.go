@@ -1,6 +1,9 @@
 package actions
 
 import (
+	"errors"
+	"path/filepath"
+
 	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
 )
 
@@ -10,15 +13,29 @@ type OpenParams struct {
 	CreateIfNotExist bool
 }
 
-func OpenNote(vault obsidian.VaultManager, uri obsidian.UriManager, params OpenParams) error {
-	vaultName, err := vault.DefaultName()
+func OpenNote(vault obsidian.VaultManager, note obsidian.NoteManager, uri obsidian.UriManager, params OpenParams) error {
+	vaultID, err := vault.ID()
+	if err != nil {
+		return err
+	}
+
+	vaultPath, err := vault.Path()
 	if err != nil {
 		return err
 	}
 
+	// Open the note the name resolves to, by path, file name or alias. Names of notes
+	// that do not exist yet are passed on to Obsidian as they are.
+	noteName := params.NoteName
+	if relPath, err := note.ResolveNote(vaultPath, params.NoteName); err == nil {
+		noteName = filepath.ToSlash(relPath)
+	} else if !errors.Is(err, obsidian.ErrNoteNotFound) {
+		return err
+	}
+
 	if params.CreateIfNotExist {
 		createUri := uri.Construct(ObsCreateUrl, map[string]string{
-			"vault":     vaultName,
+			"vault":     vaultID,
 			"file":      params.NoteName,
 			"append":    "false",
 			"overwrite": "false",
@@ -30,13 +47,13 @@ func OpenNote(vault obsidian.VaultManager, uri obsidian.UriManager, params OpenP
 		}
 	}
 
-	fileParam := params.NoteName
+	fileParam := noteName
 	if params.Section != "" {
-		fileParam = params.NoteName + "#" + params.Section
+		fileParam = noteName + "#" + params.Section
 	}
 
 	obsidianUri := uri.Construct(ObsOpenUrl, map[string]string{
-		"vault": vaultName,
+		"vault": vaultID,
 		"file":  fileParam,
 	})
 
@@ -2,10 +2,12 @@ package actions_test
 
 import (
 	"errors"
+	"path/filepath"
 	"testing"
 
 	"github.com/Yakitrak/obsidian-cli/mocks"
 	"github.com/Yakitrak/obsidian-cli/pkg/actions"
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -15,7 +17,7 @@ func TestOpenNote(t *testing.T) {
 		vault := mocks.MockVaultOperator{Name: "myVault"}
 		uri := mocks.MockUriManager{}
 		// Act
-		err := actions.OpenNote(&vault, &uri, actions.OpenParams{
+		err := actions.OpenNote(&vault, &mocks.MockNoteManager{}, &uri, actions.OpenParams{
 			NoteName: "note.md",
 		})
 		// Assert
@@ -24,6 +26,20 @@ func TestOpenNote(t *testing.T) {
 		assert.Equal(t, "note.md", uri.LastParams["file"])
 	})
 
+	t.Run("Opens the vault by its ID", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{Name: "/home/me/notes", VaultID: "a1"}
+		uri := mocks.MockUriManager{}
+		// Act
+		err := actions.OpenNote(&vault, &mocks.MockNoteManager{}, &uri, actions.OpenParams{
+			NoteName:         "note.md",
+			CreateIfNotExist: true,
+		})
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "a1", uri.LastParams["vault"])
+	})
+
 	t.Run("vault.DefaultName returns an error", func(t *testing.T) {
 		// Arrange
 		vaultDefaultNameErr := errors.New("Failed to get vault name")
@@ -31,7 +47,7 @@ func TestOpenNote(t *testing.T) {
 			DefaultNameErr: vaultDefaultNameErr,
 		}
 		// Act
-		err := actions.OpenNote(vaultOp, &mocks.MockUriManager{}, actions.OpenParams{
+		err := actions.OpenNote(vaultOp, &mocks.MockNoteManager{}, &mocks.MockUriManager{}, actions.OpenParams{
 			NoteName: "note.md",
 		})
 		// Assert
@@ -44,7 +60,7 @@ func TestOpenNote(t *testing.T) {
 			ExecuteErr: errors.New("Failed to execute URI"),
 		}
 		// Act
-		err := actions.OpenNote(&mocks.MockVaultOperator{}, &uri, actions.OpenParams{
+		err := actions.OpenNote(&mocks.MockVaultOperator{}, &mocks.MockNoteManager{}, &uri, actions.OpenParams{
 			NoteName: "note1.md",
 			Section:  "Heading One",
 		})
@@ -57,7 +73,7 @@ func TestOpenNote(t *testing.T) {
 		vault := mocks.MockVaultOperator{Name: "myVault"}
 		uri := mocks.MockUriManager{}
 
-		err := actions.OpenNote(&vault, &uri, actions.OpenParams{
+		err := actions.OpenNote(&vault, &mocks.MockNoteManager{}, &uri, actions.OpenParams{
 			NoteName: "note.md",
 			Section:  "Section Name",
 		})
@@ -70,7 +86,7 @@ func TestOpenNote(t *testing.T) {
 		vault := mocks.MockVaultOperator{Name: "myVault"}
 		uri := mocks.MockUriManager{}
 
-		err := actions.OpenNote(&vault, &uri, actions.OpenParams{
+		err := actions.OpenNote(&vault, &mocks.MockNoteManager{}, &uri, actions.OpenParams{
 			NoteName:         "note.md",
 			CreateIfNotExist: true,
 		})
@@ -79,4 +95,43 @@ func TestOpenNote(t *testing.T) {
 		assert.Equal(t, "myVault", uri.LastParams["vault"])
 		assert.Equal(t, "note.md", uri.LastParams["file"])
 	})
+
+	t.Run("Opens the note the name resolves to", func(t *testing.T) {
+		// Arrange
+		uri := mocks.MockUriManager{}
+		note := mocks.MockNoteManager{ResolvedNote: filepath.Join("dev", "JavaScript.md")}
+		// Act
+		err := actions.OpenNote(&mocks.MockVaultOperator{}, &note, &uri, actions.OpenParams{
+			NoteName: "js",
+		})
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "dev/JavaScript.md", uri.LastParams["file"])
+	})
+
+	t.Run("Passes on names of notes that do not exist yet", func(t *testing.T) {
+		// Arrange
+		uri := mocks.MockUriManager{}
+		note := mocks.MockNoteManager{ResolveNoteErr: obsidian.ErrNoteNotFound}
+		// Act
+		err := actions.OpenNote(&mocks.MockVaultOperator{}, &note, &uri, actions.OpenParams{
+			NoteName: "new note",
+		})
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "new note", uri.LastParams["file"])
+	})
+
+	t.Run("Ambiguous note name", func(t *testing.T) {
+		// Arrange
+		uri := mocks.MockUriManager{}
+		note := mocks.MockNoteManager{ResolveNoteErr: &obsidian.AmbiguousNoteError{Name: "README"}}
+		// Act
+		err := actions.OpenNote(&mocks.MockVaultOperator{}, &note, &uri, actions.OpenParams{
+			NoteName: "README",
+		})
+		// Assert
+		assert.Equal(t, note.ResolveNoteErr, err)
+		assert.Nil(t, uri.LastParams)
+	})
 }
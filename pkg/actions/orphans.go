@@ -0,0 +1,94 @@
+package actions
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+)
+
+// GraphScopeParams limits the notes reported by FindOrphans and FindDeadEnds. Links
+// from notes outside the folder still count.
+type GraphScopeParams struct {
+	Folder       string
+	ExcludeDaily bool
+}
+
+// FindOrphans returns the notes that no other note links to.
+func FindOrphans(vault obsidian.VaultManager, note obsidian.NoteManager, params GraphScopeParams) ([]string, error) {
+	return findGraphNotes(vault, note, params, (*obsidian.LinkGraph).Orphans)
+}
+
+// FindDeadEnds returns the notes that link to no other note.
+func FindDeadEnds(vault obsidian.VaultManager, note obsidian.NoteManager, params GraphScopeParams) ([]string, error) {
+	return findGraphNotes(vault, note, params, (*obsidian.LinkGraph).DeadEnds)
+}
+
+func findGraphNotes(vault obsidian.VaultManager, note obsidian.NoteManager, params GraphScopeParams, find func(*obsidian.LinkGraph) []string) ([]string, error) {
+	_, err := vault.DefaultName()
+	if err != nil {
+		return nil, err
+	}
+
+	vaultPath, err := vault.Path()
+	if err != nil {
+		return nil, err
+	}
+
+	inScope, err := graphScope(vault, vaultPath, params)
+	if err != nil {
+		return nil, err
+	}
+
+	graph, err := note.GetLinkGraph(vaultPath)
+	if err != nil {
+		return nil, err
+	}
+
+	notes := []string{}
+	for _, relPath := range find(graph) {
+		if inScope(relPath) {
+			notes = append(notes, relPath)
+		}
+	}
+	return notes, nil
+}
+
+// graphScope returns a filter accepting the notes inside params.Folder that are not
+// daily notes, when ExcludeDaily is set.
+func graphScope(vault obsidian.VaultManager, vaultPath string, params GraphScopeParams) (func(string) bool, error) {
+	var folder string
+	if params.Folder != "" {
+		folderPath, err := obsidian.ValidatePath(vaultPath, params.Folder)
+		if err != nil {
+			return nil, err
+		}
+		absVaultPath, err := filepath.Abs(vaultPath)
+		if err != nil {
+			return nil, err
+		}
+		folder, err = filepath.Rel(absVaultPath, folderPath)
+		if err != nil {
+			return nil, err
+		}
+		if folder == "." {
+			folder = ""
+		}
+	}
+
+	var isDaily func(string) bool
+	if params.ExcludeDaily {
+		pattern, err := vault.DailyNotePattern()
+		if err != nil {
+			return nil, err
+		}
+		isDaily = obsidian.DailyNoteMatcher(pattern)
+	}
+
+	return func(relPath string) bool {
+		if folder != "" && !strings.HasPrefix(relPath, folder+string(filepath.Separator)) {
+			return false
+		}
+		return isDaily == nil || !isDaily(relPath)
+	}, nil
+}
@@ -0,0 +1,91 @@
+package actions_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/Yakitrak/obsidian-cli/mocks"
+	"github.com/Yakitrak/obsidian-cli/pkg/actions"
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindOrphans(t *testing.T) {
+	daily := filepath.Join("daily", "2024-03-15.md")
+	idea := filepath.Join("daily", "idea.md")
+	project := filepath.Join("projects", "plan.md")
+	graph := &obsidian.LinkGraph{
+		Notes:    []string{"index.md", daily, idea, project},
+		Outgoing: map[string][]string{"index.md": {project}},
+		Incoming: map[string][]string{project: {"index.md"}},
+	}
+
+	t.Run("Returns notes without incoming links", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := mocks.MockNoteManager{LinkGraph: graph}
+		// Act
+		notes, err := actions.FindOrphans(&vault, &note, actions.GraphScopeParams{})
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"index.md", daily, idea}, notes)
+	})
+
+	t.Run("Scopes to a folder and excludes daily notes", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{Name: "myVault", DailyPattern: "daily/YYYY-MM-DD"}
+		note := mocks.MockNoteManager{LinkGraph: graph}
+		// Act
+		notes, err := actions.FindOrphans(&vault, &note, actions.GraphScopeParams{Folder: "daily", ExcludeDaily: true})
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []string{idea}, notes)
+	})
+
+	t.Run("Excluding daily notes needs a daily pattern", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{Name: "myVault", DailyNotePatternErr: obsidian.ErrDailyPatternNotConfigured}
+		note := mocks.MockNoteManager{LinkGraph: graph}
+		// Act
+		_, err := actions.FindOrphans(&vault, &note, actions.GraphScopeParams{ExcludeDaily: true})
+		// Assert
+		assert.ErrorIs(t, err, obsidian.ErrDailyPatternNotConfigured)
+	})
+
+	t.Run("Folder outside the vault", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := mocks.MockNoteManager{LinkGraph: graph}
+		// Act
+		_, err := actions.FindOrphans(&vault, &note, actions.GraphScopeParams{Folder: "../other"})
+		// Assert
+		assert.ErrorIs(t, err, obsidian.ErrPathTraversal)
+	})
+}
+
+func TestFindDeadEnds(t *testing.T) {
+	t.Run("Returns notes without outgoing links", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := mocks.MockNoteManager{LinkGraph: &obsidian.LinkGraph{
+			Notes:    []string{"a.md", "b.md"},
+			Outgoing: map[string][]string{"a.md": {"b.md"}},
+			Incoming: map[string][]string{"b.md": {"a.md"}},
+		}}
+		// Act
+		notes, err := actions.FindDeadEnds(&vault, &note, actions.GraphScopeParams{})
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"b.md"}, notes)
+	})
+
+	t.Run("GetLinkGraph returns an error", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := mocks.MockNoteManager{LinkGraphErr: obsidian.ErrVaultAccess}
+		// Act
+		_, err := actions.FindDeadEnds(&vault, &note, actions.GraphScopeParams{})
+		// Assert
+		assert.ErrorIs(t, err, obsidian.ErrVaultAccess)
+	})
+}
@@ -9,23 +9,33 @@ import (
 	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
 )
 
-func SearchNotes(vault obsidian.VaultManager, note obsidian.NoteManager, fuzzyFinder obsidian.FuzzyFinderManager, metadataFilters map[string]string) error {
-	vaultPath, err := vault.Path()
-	if err != nil {
+type SearchParams struct {
+	MetadataFilters map[string]string
+	// NoInteractive prints every note instead of opening the fuzzy finder
+	NoInteractive bool
+	// Format prints every note as json, ndjson or tsv, implying NoInteractive
+	Format string
+}
+
+func SearchNotes(vault obsidian.VaultManager, note obsidian.NoteManager, fuzzyFinder obsidian.FuzzyFinderManager, params SearchParams) error {
+	if err := ValidateSearchFormat(params.Format); err != nil {
 		return err
 	}
 
-	notes, err := note.GetNotesList(vaultPath)
+	notes, err := FindNotes(vault, note, params)
 	if err != nil {
 		return err
 	}
 
-	// Apply metadata filtering if filters are provided
-	if len(metadataFilters) > 0 {
-		notes, err = filterNotesByMetadata(vaultPath, notes, metadataFilters)
-		if err != nil {
-			return err
+	if params.Format != "" {
+		return WriteSearchResults(os.Stdout, params.Format, searchResultsFromNotes(notes))
+	}
+
+	if params.NoInteractive {
+		for _, note := range notes {
+			fmt.Println(note)
 		}
+		return nil
 	}
 
 	if len(notes) == 0 {
@@ -44,6 +54,28 @@ func SearchNotes(vault obsidian.VaultManager, note obsidian.NoteManager, fuzzyFi
 	return nil
 }
 
+// FindNotes returns every note matching the metadata filters without prompting.
+func FindNotes(vault obsidian.VaultManager, note obsidian.NoteManager, params SearchParams) ([]string, error) {
+	vaultPath, err := vault.Path()
+	if err != nil {
+		return nil, err
+	}
+
+	notes, err := note.GetNotesList(vaultPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// Apply metadata filtering if filters are provided
+	if len(params.MetadataFilters) > 0 {
+		notes, err = filterNotesByMetadata(vaultPath, notes, params.MetadataFilters)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return notes, nil
+}
+
 func filterNotesByMetadata(vaultPath string, notes []string, filters map[string]string) ([]string, error) {
 	var filtered []string
 
@@ -2,49 +2,47 @@ package actions
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
 )
 
-func SearchNotesContent(vault obsidian.VaultManager, note obsidian.NoteManager, fuzzyFinder obsidian.FuzzyFinderManager, searchTerm string, metadataFilters map[string]string) error {
-	vaultPath, err := vault.Path()
-	if err != nil {
+type SearchContentParams struct {
+	SearchTerm      string
+	MetadataFilters map[string]string
+	Limit           int
+	Regex           bool
+	CaseSensitive   bool
+	Word            bool
+	// NoInteractive prints every match instead of opening the fuzzy finder
+	NoInteractive bool
+	// Format prints every match as json, ndjson or tsv, implying NoInteractive
+	Format string
+}
+
+func SearchNotesContent(vault obsidian.VaultManager, note obsidian.NoteManager, fuzzyFinder obsidian.FuzzyFinderManager, params SearchContentParams) error {
+	if err := ValidateSearchFormat(params.Format); err != nil {
 		return err
 	}
 
-	matches, err := note.SearchNotesWithSnippets(vaultPath, searchTerm)
+	matches, err := searchContent(vault, note, params)
 	if err != nil {
 		return err
 	}
 
-	if len(metadataFilters) > 0 {
-		uniquePaths := make(map[string]bool)
-		for _, m := range matches {
-			uniquePaths[m.FilePath] = true
-		}
-		pathList := make([]string, 0, len(uniquePaths))
-		for p := range uniquePaths {
-			pathList = append(pathList, p)
-		}
-		filtered, err := filterNotesByMetadata(vaultPath, pathList, metadataFilters)
-		if err != nil {
-			return err
-		}
-		allowedPaths := make(map[string]bool)
-		for _, p := range filtered {
-			allowedPaths[p] = true
-		}
-		var filteredMatches []obsidian.NoteMatch
-		for _, m := range matches {
-			if allowedPaths[m.FilePath] {
-				filteredMatches = append(filteredMatches, m)
-			}
+	if params.Format != "" {
+		return WriteSearchResults(os.Stdout, params.Format, searchResultsFromMatches(matches))
+	}
+
+	if params.NoInteractive {
+		for _, item := range formatMatchesForDisplay(matches) {
+			fmt.Println(item)
 		}
-		matches = filteredMatches
+		return nil
 	}
 
 	if len(matches) == 0 {
-		fmt.Printf("No notes found containing '%s'\n", searchTerm)
+		fmt.Printf("No notes found containing '%s'\n", params.SearchTerm)
 		return nil
 	}
 
@@ -66,6 +64,30 @@ func SearchNotesContent(vault obsidian.VaultManager, note obsidian.NoteManager,
 	return nil
 }
 
+// FindNotesContent returns every match of the search in rank order without prompting.
+func FindNotesContent(vault obsidian.VaultManager, note obsidian.NoteManager, params SearchContentParams) ([]SearchResult, error) {
+	matches, err := searchContent(vault, note, params)
+	if err != nil {
+		return nil, err
+	}
+	return searchResultsFromMatches(matches), nil
+}
+
+func searchContent(vault obsidian.VaultManager, note obsidian.NoteManager, params SearchContentParams) ([]obsidian.NoteMatch, error) {
+	vaultPath, err := vault.Path()
+	if err != nil {
+		return nil, err
+	}
+
+	return note.SearchNotesWithSnippets(vaultPath, params.SearchTerm, obsidian.SearchOptions{
+		Limit:           params.Limit,
+		MetadataFilters: params.MetadataFilters,
+		Regex:           params.Regex,
+		CaseSensitive:   params.CaseSensitive,
+		Word:            params.Word,
+	})
+}
+
 func formatMatchesForDisplay(matches []obsidian.NoteMatch) []string {
 	maxPathLength := calculateMaxPathLength(matches)
 
@@ -19,6 +19,7 @@ type vaultStubForSearch struct {
 func (v *vaultStubForSearch) DefaultName() (string, error)      { return "test-vault", nil }
 func (v *vaultStubForSearch) SetDefaultName(_ string) error     { return nil }
 func (v *vaultStubForSearch) Path() (string, error)             { return v.path, nil }
+func (v *vaultStubForSearch) ID() (string, error)               { return "test-vault", nil }
 func (v *vaultStubForSearch) DailyNotePattern() (string, error) { return "", nil }
 func (v *vaultStubForSearch) ResolveDailyNote() (string, error) { return "", nil }
 
@@ -27,10 +28,11 @@ type CustomMockNoteForSingleMatch struct{}
 func (m *CustomMockNoteForSingleMatch) Delete(string) error                        { return nil }
 func (m *CustomMockNoteForSingleMatch) Move(string, string) error                  { return nil }
 func (m *CustomMockNoteForSingleMatch) UpdateLinks(string, string, string) error   { return nil }
+func (m *CustomMockNoteForSingleMatch) UnlinkNote(string, string) error            { return nil }
 func (m *CustomMockNoteForSingleMatch) GetContents(string, string) (string, error) { return "", nil }
 func (m *CustomMockNoteForSingleMatch) SetContents(string, string, string) error   { return nil }
 func (m *CustomMockNoteForSingleMatch) GetNotesList(string) ([]string, error)      { return nil, nil }
-func (m *CustomMockNoteForSingleMatch) SearchNotesWithSnippets(string, string) ([]obsidian.NoteMatch, error) {
+func (m *CustomMockNoteForSingleMatch) SearchNotesWithSnippets(string, string, obsidian.SearchOptions) ([]obsidian.NoteMatch, error) {
 	return []obsidian.NoteMatch{
 		{FilePath: "test-note.md", LineNumber: 5, MatchLine: "test content"},
 	}, nil
@@ -38,6 +40,58 @@ func (m *CustomMockNoteForSingleMatch) SearchNotesWithSnippets(string, string) (
 func (m *CustomMockNoteForSingleMatch) FindBacklinks(string, string) ([]obsidian.NoteMatch, error) {
 	return nil, nil
 }
+func (m *CustomMockNoteForSingleMatch) OutgoingLinks(string, string) ([]obsidian.ResolvedLink, error) {
+	return nil, nil
+}
+func (m *CustomMockNoteForSingleMatch) FindBrokenLinks(string) ([]obsidian.BrokenLink, error) {
+	return nil, nil
+}
+func (m *CustomMockNoteForSingleMatch) GetLinkGraph(string) (*obsidian.LinkGraph, error) {
+	return nil, nil
+}
+func (m *CustomMockNoteForSingleMatch) ExportGraph(string, obsidian.GraphExportOptions) (*obsidian.GraphExport, error) {
+	return nil, nil
+}
+
+func (m *CustomMockNoteForSingleMatch) Transact(fn func() error) error {
+	return fn()
+}
+
+func (m *CustomMockNoteForSingleMatch) ResolveNote(_ string, noteName string) (string, error) {
+	return noteName, nil
+}
+
+func (m *CustomMockNoteForSingleMatch) Trash(string, string) (string, error) {
+	return "", nil
+}
+
+func (m *CustomMockNoteForSingleMatch) ListTrash(string) ([]obsidian.TrashedNote, error) {
+	return nil, nil
+}
+
+func (m *CustomMockNoteForSingleMatch) RestoreTrash(string, string) (obsidian.TrashedNote, error) {
+	return obsidian.TrashedNote{}, nil
+}
+
+func (m *CustomMockNoteForSingleMatch) Create(string, string, bool) error {
+	return nil
+}
+
+func (m *CustomMockNoteForSingleMatch) MoveFolder(string, string) error {
+	return nil
+}
+
+func (m *CustomMockNoteForSingleMatch) UpdateFolderLinks(string, string, string) error {
+	return nil
+}
+
+func (m *CustomMockNoteForSingleMatch) FindUnlinkedMentions(string, string) ([]obsidian.Mention, error) {
+	return nil, nil
+}
+
+func (m *CustomMockNoteForSingleMatch) LinkMentions(string, string, []obsidian.Mention) ([]obsidian.Mention, error) {
+	return nil, nil
+}
 
 func TestSearchNotesContent(t *testing.T) {
 	t.Run("Successful content search with multiple matches", func(t *testing.T) {
@@ -45,7 +99,7 @@ func TestSearchNotesContent(t *testing.T) {
 		note := mocks.MockNoteManager{}
 		fuzzyFinder := mocks.MockFuzzyFinder{}
 
-		err := actions.SearchNotesContent(&vault, &note, &fuzzyFinder, "test", nil)
+		err := actions.SearchNotesContent(&vault, &note, &fuzzyFinder, actions.SearchContentParams{SearchTerm: "test"})
 		assert.NoError(t, err)
 	})
 
@@ -54,7 +108,7 @@ func TestSearchNotesContent(t *testing.T) {
 		note := &CustomMockNoteForSingleMatch{}
 		fuzzyFinder := mocks.MockFuzzyFinder{}
 
-		err := actions.SearchNotesContent(&vault, note, &fuzzyFinder, "test", nil)
+		err := actions.SearchNotesContent(&vault, note, &fuzzyFinder, actions.SearchContentParams{SearchTerm: "test"})
 		assert.NoError(t, err)
 	})
 
@@ -63,7 +117,7 @@ func TestSearchNotesContent(t *testing.T) {
 		note := mocks.MockNoteManager{NoMatches: true}
 		fuzzyFinder := mocks.MockFuzzyFinder{}
 
-		err := actions.SearchNotesContent(&vault, &note, &fuzzyFinder, "nonexistent", nil)
+		err := actions.SearchNotesContent(&vault, &note, &fuzzyFinder, actions.SearchContentParams{SearchTerm: "nonexistent"})
 		assert.NoError(t, err)
 	})
 
@@ -74,7 +128,7 @@ func TestSearchNotesContent(t *testing.T) {
 		}
 		fuzzyFinder := mocks.MockFuzzyFinder{}
 
-		err := actions.SearchNotesContent(&vault, &note, &fuzzyFinder, "test", nil)
+		err := actions.SearchNotesContent(&vault, &note, &fuzzyFinder, actions.SearchContentParams{SearchTerm: "test"})
 		assert.Error(t, err)
 	})
 
@@ -85,7 +139,7 @@ func TestSearchNotesContent(t *testing.T) {
 		note := mocks.MockNoteManager{}
 		fuzzyFinder := mocks.MockFuzzyFinder{}
 
-		err := actions.SearchNotesContent(&vault, &note, &fuzzyFinder, "test", nil)
+		err := actions.SearchNotesContent(&vault, &note, &fuzzyFinder, actions.SearchContentParams{SearchTerm: "test"})
 		assert.Error(t, err)
 	})
 
@@ -96,7 +150,7 @@ func TestSearchNotesContent(t *testing.T) {
 			FindErr: errors.New("fuzzy finder error"),
 		}
 
-		err := actions.SearchNotesContent(&vault, &note, &fuzzyFinder, "test", nil)
+		err := actions.SearchNotesContent(&vault, &note, &fuzzyFinder, actions.SearchContentParams{SearchTerm: "test"})
 		assert.Error(t, err)
 	})
 
@@ -111,8 +165,53 @@ func TestSearchNotesContent(t *testing.T) {
 		note := &obsidian.Note{}
 		fuzzyFinder := mocks.MockFuzzyFinder{}
 
-		err := actions.SearchNotesContent(vault, note, &fuzzyFinder, "keyword",
-			map[string]string{"status": "done"})
+		err := actions.SearchNotesContent(vault, note, &fuzzyFinder, actions.SearchContentParams{
+			SearchTerm:      "keyword",
+			MetadataFilters: map[string]string{"status": "done"},
+		})
 		assert.NoError(t, err)
 	})
+
+	t.Run("no-interactive and format print matches without the fuzzy finder", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := mocks.MockNoteManager{}
+		fuzzyFinder := mocks.MockFuzzyFinder{
+			FindErr: errors.New("fuzzy finder error"),
+		}
+
+		err := actions.SearchNotesContent(&vault, &note, &fuzzyFinder, actions.SearchContentParams{SearchTerm: "test", NoInteractive: true})
+		assert.NoError(t, err)
+		err = actions.SearchNotesContent(&vault, &note, &fuzzyFinder, actions.SearchContentParams{SearchTerm: "test", Format: actions.FormatTSV})
+		assert.NoError(t, err)
+	})
+
+	t.Run("unsupported format returns an error", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := mocks.MockNoteManager{}
+		fuzzyFinder := mocks.MockFuzzyFinder{}
+
+		err := actions.SearchNotesContent(&vault, &note, &fuzzyFinder, actions.SearchContentParams{SearchTerm: "test", Format: "xml"})
+		assert.Error(t, err)
+	})
+}
+
+func TestFindNotesContent(t *testing.T) {
+	t.Run("Returns every match as a search result", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := &CustomMockNoteForSingleMatch{}
+
+		results, err := actions.FindNotesContent(&vault, note, actions.SearchContentParams{SearchTerm: "test"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []actions.SearchResult{{Path: "test-note.md", Line: 5, Snippet: "test content"}}, results)
+	})
+
+	t.Run("Search error propagates", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := mocks.MockNoteManager{GetContentsError: errors.New("search failed")}
+
+		_, err := actions.FindNotesContent(&vault, &note, actions.SearchContentParams{SearchTerm: "test"})
+
+		assert.Error(t, err)
+	})
 }
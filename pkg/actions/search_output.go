@@ -0,0 +1,88 @@
+package actions
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+)
+
+const (
+	FormatJSON   = "json"
+	FormatNDJSON = "ndjson"
+	FormatTSV    = "tsv"
+)
+
+// SearchResult is a search match in machine-readable output. Line is 0 when
+// the note matched by file name only.
+type SearchResult struct {
+	Path    string  `json:"path"`
+	Line    int     `json:"line"`
+	Snippet string  `json:"snippet"`
+	Score   float64 `json:"score"`
+}
+
+// ValidateSearchFormat returns an error for output formats other than json, ndjson and tsv.
+func ValidateSearchFormat(format string) error {
+	switch format {
+	case "", FormatJSON, FormatNDJSON, FormatTSV:
+		return nil
+	}
+	return obsidian.InvalidArgument("unsupported output format %q, expected %s, %s or %s", format, FormatJSON, FormatNDJSON, FormatTSV)
+}
+
+func searchResultsFromMatches(matches []obsidian.NoteMatch) []SearchResult {
+	results := make([]SearchResult, 0, len(matches))
+	for _, match := range matches {
+		results = append(results, SearchResult{
+			Path:    match.FilePath,
+			Line:    match.LineNumber,
+			Snippet: match.MatchLine,
+			Score:   match.Score,
+		})
+	}
+	return results
+}
+
+func searchResultsFromNotes(notes []string) []SearchResult {
+	results := make([]SearchResult, 0, len(notes))
+	for _, note := range notes {
+		results = append(results, SearchResult{Path: note})
+	}
+	return results
+}
+
+// WriteSearchResults writes every result in the given format: a JSON array, one
+// JSON object per line (ndjson), or tab-separated path, line, score and snippet.
+func WriteSearchResults(w io.Writer, format string, results []SearchResult) error {
+	if err := ValidateSearchFormat(format); err != nil {
+		return err
+	}
+
+	switch format {
+	case FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(results)
+	case FormatNDJSON:
+		encoder := json.NewEncoder(w)
+		for _, result := range results {
+			if err := encoder.Encode(result); err != nil {
+				return err
+			}
+		}
+	case FormatTSV:
+		for _, result := range results {
+			// Tabs and line breaks in snippets would break the columns
+			snippet := strings.NewReplacer("\t", " ", "\r", " ", "\n", " ").Replace(result.Snippet)
+			score := strconv.FormatFloat(result.Score, 'f', -1, 64)
+			if _, err := fmt.Fprintf(w, "%s\t%d\t%s\t%s\n", result.Path, result.Line, score, snippet); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package actions_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/actions"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteSearchResults(t *testing.T) {
+	results := []actions.SearchResult{
+		{Path: "notes/a.md", Line: 3, Snippet: "first\tmatch", Score: 1.5},
+		{Path: "b.md", Line: 0, Snippet: "(filename match: b.md)", Score: 0.25},
+	}
+
+	t.Run("json writes an array", func(t *testing.T) {
+		// Arrange
+		var out bytes.Buffer
+
+		// Act
+		err := actions.WriteSearchResults(&out, actions.FormatJSON, results)
+
+		// Assert
+		assert.NoError(t, err)
+		var decoded []actions.SearchResult
+		assert.NoError(t, json.Unmarshal(out.Bytes(), &decoded))
+		assert.Equal(t, results, decoded)
+	})
+
+	t.Run("json writes an empty array when nothing matched", func(t *testing.T) {
+		// Arrange
+		var out bytes.Buffer
+
+		// Act
+		err := actions.WriteSearchResults(&out, actions.FormatJSON, []actions.SearchResult{})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "[]\n", out.String())
+	})
+
+	t.Run("ndjson writes one object per line", func(t *testing.T) {
+		// Arrange
+		var out bytes.Buffer
+
+		// Act
+		err := actions.WriteSearchResults(&out, actions.FormatNDJSON, results)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, `{"path":"notes/a.md","line":3,"snippet":"first\tmatch","score":1.5}
+{"path":"b.md","line":0,"snippet":"(filename match: b.md)","score":0.25}
+`, out.String())
+	})
+
+	t.Run("tsv writes path, line, score and snippet columns", func(t *testing.T) {
+		// Arrange
+		var out bytes.Buffer
+
+		// Act
+		err := actions.WriteSearchResults(&out, actions.FormatTSV, results)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "notes/a.md\t3\t1.5\tfirst match\nb.md\t0\t0.25\t(filename match: b.md)\n", out.String())
+	})
+
+	t.Run("unknown formats return an error", func(t *testing.T) {
+		// Arrange
+		var out bytes.Buffer
+
+		// Act
+		err := actions.WriteSearchResults(&out, "xml", results)
+
+		// Assert
+		assert.Error(t, err)
+		assert.Empty(t, out.String())
+	})
+}
@@ -14,7 +14,7 @@ func TestSearchNotes(t *testing.T) {
 		vault := mocks.MockVaultOperator{Name: "myVault"}
 		note := mocks.MockNoteManager{}
 		fuzzyFinder := mocks.MockFuzzyFinder{}
-		err := actions.SearchNotes(&vault, &note, &fuzzyFinder, nil)
+		err := actions.SearchNotes(&vault, &note, &fuzzyFinder, actions.SearchParams{})
 		assert.NoError(t, err, "Expected no error")
 	})
 
@@ -24,7 +24,7 @@ func TestSearchNotes(t *testing.T) {
 		fuzzyFinder := mocks.MockFuzzyFinder{
 			FindErr: errors.New("Fuzzy find error"),
 		}
-		err := actions.SearchNotes(&vault, &note, &fuzzyFinder, nil)
+		err := actions.SearchNotes(&vault, &note, &fuzzyFinder, actions.SearchParams{})
 		assert.Equal(t, err, fuzzyFinder.FindErr)
 	})
 
@@ -34,7 +34,37 @@ func TestSearchNotes(t *testing.T) {
 		}
 		note := mocks.MockNoteManager{}
 		fuzzyFinder := mocks.MockFuzzyFinder{}
-		err := actions.SearchNotes(&vault, &note, &fuzzyFinder, nil)
+		err := actions.SearchNotes(&vault, &note, &fuzzyFinder, actions.SearchParams{})
 		assert.Equal(t, err, vault.PathError)
 	})
+
+	t.Run("no-interactive prints notes without the fuzzy finder", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := mocks.MockNoteManager{}
+		fuzzyFinder := mocks.MockFuzzyFinder{
+			FindErr: errors.New("Fuzzy find error"),
+		}
+		err := actions.SearchNotes(&vault, &note, &fuzzyFinder, actions.SearchParams{NoInteractive: true})
+		assert.NoError(t, err)
+		err = actions.SearchNotes(&vault, &note, &fuzzyFinder, actions.SearchParams{Format: actions.FormatNDJSON})
+		assert.NoError(t, err)
+	})
+
+	t.Run("unsupported format returns an error", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := mocks.MockNoteManager{}
+		fuzzyFinder := mocks.MockFuzzyFinder{}
+		err := actions.SearchNotes(&vault, &note, &fuzzyFinder, actions.SearchParams{Format: "xml"})
+		assert.Error(t, err)
+	})
+}
+
+func TestFindNotes(t *testing.T) {
+	vault := mocks.MockVaultOperator{Name: "myVault"}
+	note := mocks.MockNoteManager{}
+
+	notes, err := actions.FindNotes(&vault, &note, actions.SearchParams{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"note1", "note2", "note3"}, notes)
 }
@@ -0,0 +1,36 @@
+package actions
+
+import (
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+)
+
+type TrashRestoreParams struct {
+	NoteName string
+}
+
+// ListTrash returns the deleted files of the vault that can be restored, most
+// recently deleted first.
+func ListTrash(vault obsidian.VaultManager, note obsidian.NoteManager) ([]obsidian.TrashedNote, error) {
+	_, err := vault.DefaultName()
+	if err != nil {
+		return nil, err
+	}
+	vaultPath, err := vault.Path()
+	if err != nil {
+		return nil, err
+	}
+	return note.ListTrash(vaultPath)
+}
+
+// RestoreTrash moves a deleted note back to where it was in the vault.
+func RestoreTrash(vault obsidian.VaultManager, note obsidian.NoteManager, params TrashRestoreParams) (obsidian.TrashedNote, error) {
+	_, err := vault.DefaultName()
+	if err != nil {
+		return obsidian.TrashedNote{}, err
+	}
+	vaultPath, err := vault.Path()
+	if err != nil {
+		return obsidian.TrashedNote{}, err
+	}
+	return note.RestoreTrash(vaultPath, params.NoteName)
+}
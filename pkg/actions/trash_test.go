@@ -0,0 +1,56 @@
+package actions_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Yakitrak/obsidian-cli/mocks"
+	"github.com/Yakitrak/obsidian-cli/pkg/actions"
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListTrash(t *testing.T) {
+	t.Run("Lists the deleted notes", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := mocks.MockNoteManager{TrashedNotes: []obsidian.TrashedNote{{Path: "note.md", Trash: obsidian.TrashLocal}}}
+		// Act
+		notes, err := actions.ListTrash(&vault, &note)
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, note.TrashedNotes, notes)
+	})
+
+	t.Run("vault.Path returns an error", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{PathError: errors.New("Failed to get vault path")}
+		// Act
+		_, err := actions.ListTrash(&vault, &mocks.MockNoteManager{})
+		// Assert
+		assert.Equal(t, vault.PathError, err)
+	})
+}
+
+func TestRestoreTrash(t *testing.T) {
+	t.Run("Restores a deleted note", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := mocks.MockNoteManager{TrashedNotes: []obsidian.TrashedNote{{Path: "note.md", Trash: obsidian.TrashLocal}}}
+		// Act
+		restored, err := actions.RestoreTrash(&vault, &note, actions.TrashRestoreParams{NoteName: "note"})
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "note.md", restored.Path)
+	})
+
+	t.Run("note.RestoreTrash returns an error", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := mocks.MockNoteManager{TrashErr: obsidian.ErrNoteNotFound}
+		// Act
+		_, err := actions.RestoreTrash(&vault, &note, actions.TrashRestoreParams{NoteName: "note"})
+		// Assert
+		assert.ErrorIs(t, err, obsidian.ErrNoteNotFound)
+	})
+}
@@ -0,0 +1,59 @@
+package actions
+
+import (
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+)
+
+type UndoParams struct {
+	Count int
+	Force bool
+}
+
+type HistoryParams struct {
+	Limit int
+}
+
+// Undo reverts the last operations recorded in the journal of the vault and
+// returns them, most recent first.
+func Undo(vault obsidian.VaultManager, params UndoParams) ([]obsidian.JournalOperation, error) {
+	_, err := vault.DefaultName()
+	if err != nil {
+		return nil, err
+	}
+	vaultPath, err := vault.Path()
+	if err != nil {
+		return nil, err
+	}
+
+	count := params.Count
+	if count == 0 {
+		count = 1
+	}
+	return obsidian.NewJournal(vaultPath, "").Undo(count, params.Force)
+}
+
+// History returns the operations recorded in the journal of the vault, most
+// recent first. A Limit above zero keeps only that many.
+func History(vault obsidian.VaultManager, params HistoryParams) ([]obsidian.JournalOperation, error) {
+	_, err := vault.DefaultName()
+	if err != nil {
+		return nil, err
+	}
+	vaultPath, err := vault.Path()
+	if err != nil {
+		return nil, err
+	}
+
+	operations, err := obsidian.NewJournal(vaultPath, "").Operations()
+	if err != nil {
+		return nil, err
+	}
+	history := make([]obsidian.JournalOperation, 0, len(operations))
+	for i := len(operations) - 1; i >= 0; i-- {
+		if params.Limit > 0 && len(history) == params.Limit {
+			break
+		}
+		history = append(history, operations[i])
+	}
+	return history, nil
+}
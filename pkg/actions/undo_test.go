@@ -0,0 +1,79 @@
+package actions_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Yakitrak/obsidian-cli/mocks"
+	"github.com/Yakitrak/obsidian-cli/pkg/actions"
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUndo(t *testing.T) {
+	t.Run("Reverts the last operation", func(t *testing.T) {
+		// Arrange
+		vaultDir := t.TempDir()
+		vault := mocks.MockVaultOperator{Name: "myVault", VaultPath: vaultDir}
+		note := obsidian.Note{Journal: obsidian.NewJournal(vaultDir, "create note")}
+		assert.NoError(t, note.Create(filepath.Join(vaultDir, "note.md"), "content", false))
+
+		// Act
+		operations, err := actions.Undo(&vault, actions.UndoParams{})
+
+		// Assert
+		assert.NoError(t, err)
+		if assert.Len(t, operations, 1) {
+			assert.Equal(t, "create note", operations[0].Command)
+		}
+		_, statErr := os.Stat(filepath.Join(vaultDir, "note.md"))
+		assert.True(t, os.IsNotExist(statErr))
+	})
+
+	t.Run("vault.Path returns an error", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{PathError: errors.New("Failed to get vault path")}
+
+		// Act
+		_, err := actions.Undo(&vault, actions.UndoParams{})
+
+		// Assert
+		assert.Equal(t, vault.PathError, err)
+	})
+}
+
+func TestHistory(t *testing.T) {
+	t.Run("Lists the most recent operations first", func(t *testing.T) {
+		// Arrange
+		vaultDir := t.TempDir()
+		vault := mocks.MockVaultOperator{Name: "myVault", VaultPath: vaultDir}
+		for _, name := range []string{"first", "second", "third"} {
+			note := obsidian.Note{Journal: obsidian.NewJournal(vaultDir, "create "+name)}
+			assert.NoError(t, note.Create(filepath.Join(vaultDir, name+".md"), name, false))
+		}
+
+		// Act
+		operations, err := actions.History(&vault, actions.HistoryParams{Limit: 2})
+
+		// Assert
+		assert.NoError(t, err)
+		if assert.Len(t, operations, 2) {
+			assert.Equal(t, "create third", operations[0].Command)
+			assert.Equal(t, "create second", operations[1].Command)
+		}
+	})
+
+	t.Run("Empty journal", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{Name: "myVault", VaultPath: t.TempDir()}
+
+		// Act
+		operations, err := actions.History(&vault, actions.HistoryParams{})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Empty(t, operations)
+	})
+}
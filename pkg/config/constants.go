@@ -6,4 +6,6 @@ const (
 	ObsidianConfigFile                      = "obsidian.json"
 	ObsidianCLIConfigDirectory              = "obsidian-cli"
 	ObsidianCLIConfigFile                   = "preferences.json"
+	ObsidianCLIIndexDirectory               = "index"
+	ObsidianCLIJournalDirectory             = "journal"
 )
@@ -0,0 +1,24 @@
+package config
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"path/filepath"
+)
+
+// IndexFile returns the location of the cached note index for the vault at vaultPath.
+// Each vault gets its own file, named after a hash of its path.
+func IndexFile(vaultPath string) (indexFile string, err error) {
+	cliConfigDir, _, err := CliPath()
+	if err != nil {
+		return "", err
+	}
+	indexFile = filepath.Join(cliConfigDir, ObsidianCLIIndexDirectory, vaultKey(vaultPath)+".json")
+	return indexFile, nil
+}
+
+// vaultKey names the files the CLI keeps for the vault at vaultPath.
+func vaultKey(vaultPath string) string {
+	sum := sha1.Sum([]byte(vaultPath))
+	return hex.EncodeToString(sum[:8])
+}
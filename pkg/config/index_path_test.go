@@ -0,0 +1,43 @@
+package config_test
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigIndexFile(t *testing.T) {
+	originalUserConfigDirectory := config.UserConfigDirectory
+	defer func() { config.UserConfigDirectory = originalUserConfigDirectory }()
+
+	t.Run("Index file is stored per vault in the CLI config directory", func(t *testing.T) {
+		// Arrange
+		config.UserConfigDirectory = func() (string, error) {
+			return "user/config/dir", nil
+		}
+		// Act
+		first, err := config.IndexFile("/path/to/vault1")
+		assert.NoError(t, err)
+		second, err := config.IndexFile("/path/to/vault2")
+		assert.NoError(t, err)
+		// Assert
+		assert.Equal(t, "user/config/dir/obsidian-cli/index", filepath.Dir(first))
+		assert.Equal(t, ".json", filepath.Ext(first))
+		assert.NotEqual(t, first, second)
+	})
+
+	t.Run("UserConfigDir func returns an error", func(t *testing.T) {
+		// Arrange
+		config.UserConfigDirectory = func() (string, error) {
+			return "", errors.New(config.UserConfigDirectoryNotFoundErrorMessage)
+		}
+		// Act
+		indexFile, err := config.IndexFile("/path/to/vault")
+		// Assert
+		assert.Equal(t, config.UserConfigDirectoryNotFoundErrorMessage, err.Error())
+		assert.Equal(t, "", indexFile)
+	})
+}
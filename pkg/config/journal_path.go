@@ -0,0 +1,15 @@
+package config
+
+import (
+	"path/filepath"
+)
+
+// JournalDir returns the folder holding the operation journal of the vault at
+// vaultPath. Like the index, each vault gets its own folder.
+func JournalDir(vaultPath string) (journalDir string, err error) {
+	cliConfigDir, _, err := CliPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(cliConfigDir, ObsidianCLIJournalDirectory, vaultKey(vaultPath)), nil
+}
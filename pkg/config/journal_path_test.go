@@ -0,0 +1,42 @@
+package config_test
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigJournalDir(t *testing.T) {
+	originalUserConfigDirectory := config.UserConfigDirectory
+	defer func() { config.UserConfigDirectory = originalUserConfigDirectory }()
+
+	t.Run("Journal is stored per vault in the CLI config directory", func(t *testing.T) {
+		// Arrange
+		config.UserConfigDirectory = func() (string, error) {
+			return "user/config/dir", nil
+		}
+		// Act
+		first, err := config.JournalDir("/path/to/vault1")
+		assert.NoError(t, err)
+		second, err := config.JournalDir("/path/to/vault2")
+		assert.NoError(t, err)
+		// Assert
+		assert.Equal(t, "user/config/dir/obsidian-cli/journal", filepath.Dir(first))
+		assert.NotEqual(t, first, second)
+	})
+
+	t.Run("UserConfigDir func returns an error", func(t *testing.T) {
+		// Arrange
+		config.UserConfigDirectory = func() (string, error) {
+			return "", errors.New(config.UserConfigDirectoryNotFoundErrorMessage)
+		}
+		// Act
+		journalDir, err := config.JournalDir("/path/to/vault")
+		// Assert
+		assert.Equal(t, config.UserConfigDirectoryNotFoundErrorMessage, err.Error())
+		assert.Equal(t, "", journalDir)
+	})
+}
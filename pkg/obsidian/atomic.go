@@ -0,0 +1,53 @@
+package obsidian
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+var utf8BOM = []byte("\xef\xbb\xbf")
+
+// writeFileAtomic replaces the file at path with content. The content is written
+// to a temporary file next to it, synced and renamed into place, so the file is
+// never left half written. An existing file keeps its mode; a new file is created
+// with perm. Failures are reported with message.
+func writeFileAtomic(path string, content []byte, perm fs.FileMode, message string) error {
+	if info, err := os.Stat(path); err == nil {
+		perm = info.Mode().Perm()
+	}
+	tempPath, err := writeTempFile(path, content, perm)
+	if err != nil {
+		return newWriteError(message, path, errors.Unwrap(err))
+	}
+	if err := os.Rename(tempPath, path); err != nil {
+		os.Remove(tempPath)
+		return newWriteError(message, path, err)
+	}
+	syncDir(filepath.Dir(path))
+	return nil
+}
+
+// matchTextFormat returns new content for a file in the format of its current
+// content: with a UTF-8 byte order mark if it had one, and with CRLF line endings
+// if all of its lines ended that way.
+func matchTextFormat(original []byte, content []byte) []byte {
+	content = matchLineEndings(original, content)
+	if bytes.HasPrefix(original, utf8BOM) && !bytes.HasPrefix(content, utf8BOM) {
+		content = append(append([]byte{}, utf8BOM...), content...)
+	}
+	return content
+}
+
+// matchLineEndings converts the line endings of content to CRLF if original uses
+// CRLF throughout. Files with mixed line endings are left as they are.
+func matchLineEndings(original []byte, content []byte) []byte {
+	crlf := bytes.Count(original, []byte("\r\n"))
+	if crlf == 0 || crlf != bytes.Count(original, []byte("\n")) {
+		return content
+	}
+	content = bytes.ReplaceAll(content, []byte("\r\n"), []byte("\n"))
+	return bytes.ReplaceAll(content, []byte("\n"), []byte("\r\n"))
+}
@@ -0,0 +1,89 @@
+package obsidian_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNote_PreservesFileFormat(t *testing.T) {
+	const bom = "\xef\xbb\xbf"
+
+	t.Run("SetContents keeps CRLF line endings and the byte order mark", func(t *testing.T) {
+		// Arrange
+		vaultDir := t.TempDir()
+		writeVaultFiles(t, vaultDir, map[string]string{"note.md": bom + "# Title\r\nold\r\n"})
+		noteManager := obsidian.Note{}
+
+		// Act
+		err := noteManager.SetContents(vaultDir, "note", "# Title\nnew\n")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, bom+"# Title\r\nnew\r\n", readVaultFile(t, filepath.Join(vaultDir, "note.md")))
+	})
+
+	t.Run("SetContents keeps the file mode", func(t *testing.T) {
+		// Arrange
+		vaultDir := t.TempDir()
+		path := filepath.Join(vaultDir, "note.md")
+		assert.NoError(t, os.WriteFile(path, []byte("old"), 0600))
+		noteManager := obsidian.Note{}
+
+		// Act
+		err := noteManager.SetContents(vaultDir, "note", "new")
+
+		// Assert
+		assert.NoError(t, err)
+		info, _ := os.Stat(path)
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+		entries, _ := os.ReadDir(vaultDir)
+		assert.Len(t, entries, 1, "no temporary files are left behind")
+	})
+
+	t.Run("Appended text follows the line endings of the note", func(t *testing.T) {
+		// Arrange
+		vaultDir := t.TempDir()
+		path := filepath.Join(vaultDir, "note.md")
+		writeVaultFiles(t, vaultDir, map[string]string{"note.md": "a\r\n"})
+		noteManager := obsidian.Note{}
+
+		// Act
+		err := noteManager.Create(path, "b\nc\n", true)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "a\r\nb\r\nc\r\n", readVaultFile(t, path))
+	})
+
+	t.Run("Link updates keep the byte order mark", func(t *testing.T) {
+		// Arrange
+		vaultDir := t.TempDir()
+		writeVaultFiles(t, vaultDir, map[string]string{"a.md": bom + "See [[old]]\r\n", "old.md": ""})
+		noteManager := obsidian.Note{}
+
+		// Act
+		err := noteManager.UpdateLinks(vaultDir, "old", "new")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, bom+"See [[new]]\r\n", readVaultFile(t, filepath.Join(vaultDir, "a.md")))
+	})
+
+	t.Run("Files with mixed line endings are written as given", func(t *testing.T) {
+		// Arrange
+		vaultDir := t.TempDir()
+		writeVaultFiles(t, vaultDir, map[string]string{"note.md": "a\r\nb\n"})
+		noteManager := obsidian.Note{}
+
+		// Act
+		err := noteManager.SetContents(vaultDir, "note", "a\nb\nc\n")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "a\nb\nc\n", readVaultFile(t, filepath.Join(vaultDir, "note.md")))
+	})
+}
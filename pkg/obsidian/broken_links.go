@@ -0,0 +1,179 @@
+package obsidian
+
+import (
+	"path"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Reasons a link is reported as broken.
+const (
+	MissingNote    = "missing_note"
+	MissingFile    = "missing_file"
+	MissingHeading = "missing_heading"
+	MissingBlock   = "missing_block"
+)
+
+// maxSuggestions is the number of closest notes suggested for a link to a missing note.
+const maxSuggestions = 3
+
+// BrokenLink is a link whose target note, attachment, heading or block does not exist.
+// For links to missing notes, Suggestions holds the vault-relative paths of the notes
+// with the closest names, best match first, and Fix the one note that is closer than
+// all others, if there is one.
+type BrokenLink struct {
+	Source string `json:"source"`
+	Link
+	Reason      string   `json:"reason"`
+	Suggestions []string `json:"suggestions,omitempty"`
+	Fix         string   `json:"fix,omitempty"`
+}
+
+// BrokenLinks checks the links of every note in the vault and returns those that do
+// not resolve, in vault walk order.
+func (idx *VaultIndex) BrokenLinks() []BrokenLink {
+	var broken []BrokenLink
+	for _, relPath := range idx.order {
+		for _, link := range idx.ResolveLinks(relPath) {
+			reason := idx.brokenReason(link)
+			if reason == "" {
+				continue
+			}
+			brokenLink := BrokenLink{Source: relPath, Link: link.Link, Reason: reason}
+			if reason == MissingNote {
+				brokenLink.Suggestions, brokenLink.Fix = idx.SuggestNotes(link.Target)
+			}
+			broken = append(broken, brokenLink)
+		}
+	}
+	return broken
+}
+
+func (idx *VaultIndex) brokenReason(link ResolvedLink) string {
+	if !link.Exists {
+		if ext := path.Ext(link.Target); ext != "" && ext != ".md" && isAttachmentExtension(ext) {
+			return MissingFile
+		}
+		return MissingNote
+	}
+
+	entry, ok := idx.Entries[link.Path]
+	if !ok || entry.unparsed() {
+		// Attachments and notes that are not indexed have no headings or blocks to check
+		return ""
+	}
+	if link.Heading != "" && !hasHeading(entry.Headings, link.Heading) {
+		return MissingHeading
+	}
+	if link.Block != "" && !containsFold(entry.Blocks, link.Block) {
+		return MissingBlock
+	}
+	return ""
+}
+
+// isAttachmentExtension reports whether ext looks like a file extension rather than
+// part of a note name such as "v1.2".
+func isAttachmentExtension(ext string) bool {
+	return strings.IndexFunc(ext[1:], unicode.IsLetter) >= 0
+}
+
+// hasHeading reports whether a heading reference, which may name nested headings
+// as "Heading#Subheading", matches headings of the note. Punctuation is ignored
+// because Obsidian drops characters such as ":" from heading links.
+func hasHeading(headings []string, reference string) bool {
+	for _, part := range strings.Split(reference, "#") {
+		found := false
+		for _, heading := range headings {
+			if normalizeHeading(heading) == normalizeHeading(part) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func normalizeHeading(heading string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(heading), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	}), " ")
+}
+
+func containsFold(values []string, want string) bool {
+	for _, value := range values {
+		if strings.EqualFold(value, want) {
+			return true
+		}
+	}
+	return false
+}
+
+// SuggestNotes returns the notes whose names are closest to a link target by edit
+// distance, best match first, and the best match if no other note is as close.
+// Names further away than a third of the target's length (and at least two edits)
+// are not suggested.
+func (idx *VaultIndex) SuggestNotes(target string) ([]string, string) {
+	name := strings.ToLower(RemoveMdSuffix(path.Base(normalizePathSeparators(target))))
+	limit := len([]rune(name)) / 3
+	if limit < 2 {
+		limit = 2
+	}
+
+	type suggestion struct {
+		path     string
+		distance int
+	}
+	var suggestions []suggestion
+	for _, relPath := range idx.order {
+		noteName := strings.ToLower(RemoveMdSuffix(path.Base(normalizePathSeparators(relPath))))
+		if distance := levenshtein(name, noteName); distance <= limit {
+			suggestions = append(suggestions, suggestion{relPath, distance})
+		}
+	}
+	sort.SliceStable(suggestions, func(i, j int) bool { return suggestions[i].distance < suggestions[j].distance })
+
+	var paths []string
+	for i := 0; i < len(suggestions) && i < maxSuggestions; i++ {
+		paths = append(paths, suggestions[i].path)
+	}
+
+	var best string
+	if len(suggestions) == 1 || (len(suggestions) > 1 && suggestions[0].distance < suggestions[1].distance) {
+		best = suggestions[0].path
+	}
+	return paths, best
+}
+
+// levenshtein returns the number of single character insertions, deletions and
+// substitutions needed to turn a into b.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = minInt(previous[j]+1, minInt(current[j-1]+1, previous[j-1]+cost))
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)]
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,86 @@
+package obsidian_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNote_FindBrokenLinks(t *testing.T) {
+	t.Run("Reports missing notes, files, headings and blocks", func(t *testing.T) {
+		// Arrange
+		vaultDir := t.TempDir()
+		writeVaultFiles(t, vaultDir, map[string]string{
+			"source.md": "[[Plan#Goals: 2024]] [[Plan#Nope]] [[Plan#^abc]] [[Plan#^zzz]]\n![[gone.png]] [[v1.2]] [[#Local]] [[#Missing]]\n# Local",
+			"Plan.md":   "# Goals 2024\ntext ^abc",
+		})
+		noteManager := obsidian.Note{}
+
+		// Act
+		broken, err := noteManager.FindBrokenLinks(vaultDir)
+
+		// Assert
+		assert.NoError(t, err)
+		var reasons []string
+		for _, link := range broken {
+			reasons = append(reasons, link.Source+":"+link.Reason)
+		}
+		assert.Equal(t, []string{
+			"source.md:" + obsidian.MissingHeading,
+			"source.md:" + obsidian.MissingBlock,
+			"source.md:" + obsidian.MissingFile,
+			"source.md:" + obsidian.MissingNote,
+			"source.md:" + obsidian.MissingHeading,
+		}, reasons)
+		assert.Equal(t, "Nope", broken[0].Heading)
+		assert.Equal(t, 1, broken[0].Line)
+		assert.Equal(t, "v1.2", broken[3].Target)
+		assert.Equal(t, 2, broken[3].Line)
+	})
+
+	t.Run("Suggests the notes with the closest names", func(t *testing.T) {
+		// Arrange
+		vaultDir := t.TempDir()
+		writeVaultFiles(t, vaultDir, map[string]string{
+			"source.md":                "[[Projct Plan]] [[Meetng]] [[Something else entirely]]",
+			"projects/Project Plan.md": "plan",
+			"Meeting.md":               "a",
+			"Meetings.md":              "b",
+		})
+		noteManager := obsidian.Note{}
+
+		// Act
+		broken, err := noteManager.FindBrokenLinks(vaultDir)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, broken, 3)
+		assert.Equal(t, []string{filepath.Join("projects", "Project Plan.md")}, broken[0].Suggestions)
+		assert.Equal(t, filepath.Join("projects", "Project Plan.md"), broken[0].Fix)
+		assert.Equal(t, []string{"Meeting.md", "Meetings.md"}, broken[1].Suggestions)
+		assert.Equal(t, "Meeting.md", broken[1].Fix)
+		assert.Empty(t, broken[2].Suggestions)
+		assert.Empty(t, broken[2].Fix)
+	})
+
+	t.Run("No fix when several notes are equally close", func(t *testing.T) {
+		// Arrange
+		vaultDir := t.TempDir()
+		writeVaultFiles(t, vaultDir, map[string]string{
+			"source.md": "[[note]]",
+			"a/nose.md": "a",
+			"b/nope.md": "b",
+		})
+		noteManager := obsidian.Note{}
+
+		// Act
+		broken, err := noteManager.FindBrokenLinks(vaultDir)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, broken[0].Suggestions, 2)
+		assert.Empty(t, broken[0].Fix)
+	})
+}
@@ -14,5 +14,15 @@ const (
 	ObsidianConfigReadError              = "Failed to read Obsidian config file. Please ensure vault has been set up in Obsidian."
 	ObsidianConfigParseError             = "Failed to parse Obsidian config file. Please ensure vault has been set up in Obsidian."
 	ObsidianConfigVaultNotFoundError     = "Vault not found in Obsidian config file. Please ensure vault has been set up in Obsidian."
+	ObsidianConfigWriteError             = "Failed to write Obsidian config file. Please ensure you have correct permissions."
+	VaultFolderNotFoundError             = "Vault folder does not exist"
+	InvalidArgumentError                 = "Invalid argument"
+	VaultAlreadyInitializedError         = "Folder is already an Obsidian vault"
+	VaultNotRegisteredError              = "Obsidian can only open vaults it knows; this command cannot be used with --vault-path or OBSIDIAN_VAULT_PATH"
 	ObsidianCLIDailyPatternNotConfigured = "Daily note pattern not configured. Use 'obsidian set-daily-pattern <pattern>' to configure. Example: 'daily/YYYY-MM-DD'"
+	IndexWriteError                      = "Failed to write vault index. Please ensure you have correct permissions."
+	JournalWriteError                    = "Failed to write the operation journal. Please ensure you have correct permissions."
+	NothingToUndoError                   = "Nothing to undo"
+	SearchQueryParseError                = "Failed to parse search query"
+	SearchRegexError                     = "Invalid regular expression"
 )
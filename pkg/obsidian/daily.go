@@ -1,8 +1,11 @@
 package obsidian
 
 import (
+	"path"
+	"regexp"
 	"strings"
 	"time"
+	"unicode/utf8"
 )
 
 const DailyReference = "@daily"
@@ -21,6 +24,60 @@ func ExpandDatePattern(pattern string, t time.Time) string {
 	return replacer.Replace(pattern)
 }
 
+// datePatternTokens maps the tokens of ExpandDatePattern to the text they expand to,
+// longest token first so MMMM is not read as two MM tokens.
+var datePatternTokens = []struct {
+	token  string
+	regexp string
+}{
+	{"YYYY", `\d{4}`},
+	{"MMMM", `[A-Za-z]+`},
+	{"MMM", `[A-Za-z]{3}`},
+	{"YY", `\d{2}`},
+	{"MM", `\d{2}`},
+	{"DD", `\d{2}`},
+}
+
+// DatePatternRegexp returns a regular expression matching every note name
+// ExpandDatePattern can produce from the pattern.
+func DatePatternRegexp(pattern string) *regexp.Regexp {
+	var expr strings.Builder
+	expr.WriteString("^")
+	for rest := pattern; rest != ""; {
+		matched := false
+		for _, t := range datePatternTokens {
+			if strings.HasPrefix(rest, t.token) {
+				expr.WriteString(t.regexp)
+				rest = rest[len(t.token):]
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			_, size := utf8.DecodeRuneInString(rest)
+			expr.WriteString(regexp.QuoteMeta(rest[:size]))
+			rest = rest[size:]
+		}
+	}
+	expr.WriteString("$")
+	return regexp.MustCompile(expr.String())
+}
+
+// DailyNoteMatcher returns a function reporting whether a vault-relative note path is
+// a daily note created from the pattern. Patterns without a folder match the file
+// name in any folder, since daily notes are looked up by name.
+func DailyNoteMatcher(pattern string) func(notePath string) bool {
+	re := DatePatternRegexp(pattern)
+	byName := !strings.Contains(pattern, "/")
+	return func(notePath string) bool {
+		name := RemoveMdSuffix(normalizePathSeparators(notePath))
+		if byName {
+			name = path.Base(name)
+		}
+		return re.MatchString(name)
+	}
+}
+
 // IsDailyReference checks if the note name is the @daily special reference
 func IsDailyReference(noteName string) bool {
 	return noteName == DailyReference
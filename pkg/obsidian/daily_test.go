@@ -54,3 +54,27 @@ func TestIsDailyReference(t *testing.T) {
 		})
 	}
 }
+
+func TestDailyNoteMatcher(t *testing.T) {
+	tests := []struct {
+		name     string
+		pattern  string
+		notePath string
+		expected bool
+	}{
+		{"matching folder pattern", "daily/YYYY-MM-DD", "daily/2024-03-15.md", true},
+		{"other folder", "daily/YYYY-MM-DD", "notes/2024-03-15.md", false},
+		{"not a date", "daily/YYYY-MM-DD", "daily/ideas.md", false},
+		{"pattern without folder matches any folder", "YYYY-MM-DD", "journal/2024-03-15.md", true},
+		{"nested date folders", "YYYY/MM/YYYY-MM-DD", "2024/03/2024-03-15.md", true},
+		{"month names", "MMMM DD, YYYY", "March 15, 2024.md", true},
+		{"special characters are literal", "daily (YYYY).MM", "daily (2024).03.md", true},
+		{"special characters do not match anything", "daily (YYYY).MM", "daily (2024)x03.md", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, DailyNoteMatcher(tt.pattern)(tt.notePath))
+		})
+	}
+}
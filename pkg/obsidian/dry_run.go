@@ -0,0 +1,359 @@
+package obsidian
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// Kinds of change to a file recorded by a RecordingNote.
+const (
+	FileCreated  = "created"
+	FileModified = "modified"
+	FileMoved    = "moved"
+	FileDeleted  = "deleted"
+)
+
+// FileChange is a change a command would make to a file of the vault. Paths are
+// vault-relative with forward slashes. Diff is a unified diff of the content of
+// notes; it is empty for attachments and for notes moved without edits.
+type FileChange struct {
+	Path    string `json:"path"`
+	NewPath string `json:"new_path,omitempty"`
+	Status  string `json:"status"`
+	Diff    string `json:"diff,omitempty"`
+}
+
+// RecordingNote is a NoteManager that reads notes from disk but keeps every write
+// in memory instead of applying it, so the changes a command would make can be
+// reviewed with Changes. Later reads see the recorded writes.
+type RecordingNote struct {
+	Note
+
+	files map[string]*recordedFile
+	order []string
+}
+
+// recordedFile is the state of a file before and after the recorded writes.
+type recordedFile struct {
+	path    string
+	newPath string
+	existed bool
+	deleted bool
+	text    bool
+	before  string
+	after   string
+}
+
+func NewRecordingNote() *RecordingNote {
+	return &RecordingNote{files: make(map[string]*recordedFile)}
+}
+
+// file returns the recorded state of the file at an absolute path, reading its
+// current content on first use.
+func (r *RecordingNote) file(path string) *recordedFile {
+	if file, ok := r.files[path]; ok {
+		return file
+	}
+
+	file := &recordedFile{path: path, text: filepath.Ext(path) == ".md"}
+	if _, err := os.Stat(path); err == nil {
+		file.existed = true
+		if file.text {
+			if content, err := os.ReadFile(path); err == nil {
+				file.before = string(content)
+			}
+		}
+	}
+	file.after = file.before
+	r.files[path] = file
+	r.order = append(r.order, path)
+	return file
+}
+
+// current returns the recorded file at an absolute path if the recorded writes
+// changed it, and whether the file exists after them.
+func (r *RecordingNote) current(path string) (*recordedFile, bool) {
+	file, ok := r.files[path]
+	if !ok {
+		_, err := os.Stat(path)
+		return nil, err == nil
+	}
+	return file, !file.deleted && file.newPath == ""
+}
+
+// Transact runs fn. Recorded changes are never applied, so there is nothing to
+// roll back.
+func (r *RecordingNote) Transact(fn func() error) error {
+	return fn()
+}
+
+func (r *RecordingNote) Create(path string, content string, appendContent bool) error {
+	file := r.file(path)
+	if appendContent {
+		file.after += string(matchLineEndings([]byte(file.after), []byte(content)))
+	} else {
+		file.after = string(matchTextFormat([]byte(file.after), []byte(content)))
+	}
+	file.deleted = false
+	return nil
+}
+
+func (r *RecordingNote) Move(originalPath string, newPath string) error {
+	o := AddMdSuffix(originalPath)
+	n := AddMdSuffix(newPath)
+	if _, exists := r.current(o); !exists {
+		return ErrNoteNotFound
+	}
+	// Like os.Rename, moving into a folder that does not exist fails
+	if _, err := os.Stat(filepath.Dir(n)); err != nil {
+		return ErrNoteNotFound
+	}
+	r.file(o).newPath = n
+	return nil
+}
+
+func (r *RecordingNote) Delete(path string) error {
+	note := AddMdSuffix(path)
+	if _, exists := r.current(note); !exists {
+		return ErrNoteNotFound
+	}
+	r.file(note).deleted = true
+	return nil
+}
+
+// Trash records the move of a note to the trash, or its removal when the vault
+// deletes files permanently.
+func (r *RecordingNote) Trash(vaultPath string, path string) (string, error) {
+	note := AddMdSuffix(path)
+	if _, exists := r.current(note); !exists {
+		return "", ErrNoteNotFound
+	}
+	absVaultPath, err := filepath.Abs(vaultPath)
+	if err != nil {
+		return "", ErrVaultAccess
+	}
+	trashed, err := trashLocation(absVaultPath, note)
+	if err != nil {
+		return "", err
+	}
+	if trashed == nil {
+		return "", r.Delete(path)
+	}
+	if trashed.info != "" {
+		r.file(trashed.info).after = string(trashInfo(note, *trashed.DeletedAt))
+	}
+	r.file(note).newPath = trashed.Location
+	return trashed.Location, nil
+}
+
+func (r *RecordingNote) RestoreTrash(vaultPath string, noteName string) (TrashedNote, error) {
+	trashed, err := r.ListTrash(vaultPath)
+	if err != nil {
+		return TrashedNote{}, err
+	}
+	note, err := findTrashedNote(trashed, noteName)
+	if err != nil {
+		return TrashedNote{}, err
+	}
+	absVaultPath, err := absoluteVaultPath(vaultPath)
+	if err != nil {
+		return TrashedNote{}, err
+	}
+	destination := filepath.Join(absVaultPath, filepath.FromSlash(note.Path))
+	if _, exists := r.current(destination); exists {
+		return TrashedNote{}, fmt.Errorf("Cannot restore %s, a file with that name already exists", note.Path)
+	}
+	r.file(note.Location).newPath = destination
+	if note.info != "" {
+		r.file(note.info).deleted = true
+	}
+	return note, nil
+}
+
+func (r *RecordingNote) GetContents(vaultPath string, noteName string) (string, error) {
+	idx, relPath, err := findIndexedNote(vaultPath, noteName)
+	if err != nil {
+		return "", err
+	}
+	file, exists := r.current(filepath.Join(idx.VaultPath, relPath))
+	if !exists {
+		return "", ErrNoteNotFound
+	}
+	if file != nil {
+		return file.after, nil
+	}
+	return r.Note.GetContents(vaultPath, noteName)
+}
+
+func (r *RecordingNote) SetContents(vaultPath string, noteName string, content string) error {
+	idx, relPath, err := findIndexedNote(vaultPath, noteName)
+	if err != nil {
+		return err
+	}
+	file := r.file(filepath.Join(idx.VaultPath, relPath))
+	file.after = string(matchTextFormat([]byte(file.after), []byte(content)))
+	return nil
+}
+
+func (r *RecordingNote) UpdateLinks(vaultPath string, oldNoteName string, newNoteName string) error {
+	idx, err := LoadIndex(vaultPath)
+	if err != nil {
+		return err
+	}
+	return updateLinks(idx, recordingFiles{r, idx}, oldNoteName, newNoteName)
+}
+
+func (r *RecordingNote) UnlinkNote(vaultPath string, noteName string) error {
+	idx, err := LoadIndex(vaultPath)
+	if err != nil {
+		return err
+	}
+	return unlinkNote(idx, recordingFiles{r, idx}, noteName)
+}
+
+// MoveFolder records a move of every file inside the folder.
+func (r *RecordingNote) MoveFolder(originalPath string, newPath string) error {
+	if err := checkFolderMove(originalPath, newPath); err != nil {
+		return err
+	}
+
+	return filepath.WalkDir(originalPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+		relPath, err := filepath.Rel(originalPath, path)
+		if err != nil {
+			return nil
+		}
+		r.file(path).newPath = filepath.Join(newPath, relPath)
+		return nil
+	})
+}
+
+func (r *RecordingNote) UpdateFolderLinks(vaultPath string, oldFolder string, newFolder string) error {
+	idx, err := LoadIndex(vaultPath)
+	if err != nil {
+		return err
+	}
+	return updateFolderLinks(idx, recordingFiles{r, idx}, oldFolder, newFolder)
+}
+
+func (r *RecordingNote) LinkMentions(vaultPath string, noteName string, mentions []Mention) ([]Mention, error) {
+	idx, relPath, err := findIndexedNote(vaultPath, noteName)
+	if err != nil {
+		return nil, err
+	}
+	return idx.linkMentions(relPath, mentions, recordingFiles{r, idx})
+}
+
+// Changes returns the recorded changes in the order the files were first written,
+// with paths relative to the vault. Files written back unchanged are left out.
+func (r *RecordingNote) Changes(vaultPath string) []FileChange {
+	absVaultPath, err := filepath.Abs(vaultPath)
+	if err != nil {
+		absVaultPath = vaultPath
+	}
+	relative := func(path string) string {
+		if relPath, err := filepath.Rel(absVaultPath, path); err == nil {
+			path = relPath
+		}
+		return normalizePathSeparators(path)
+	}
+
+	changes := []FileChange{}
+	for _, path := range r.order {
+		file := r.files[path]
+		change := FileChange{Path: relative(file.path)}
+		fromFile, toFile := "a/"+change.Path, "b/"+change.Path
+		before, after := file.before, file.after
+		switch {
+		case file.deleted:
+			if !file.existed {
+				continue
+			}
+			change.Status = FileDeleted
+			toFile, after = "/dev/null", ""
+		case !file.existed:
+			change.Status = FileCreated
+			fromFile = "/dev/null"
+		case file.newPath != "":
+			change.Status = FileMoved
+			change.NewPath = relative(file.newPath)
+			toFile = "b/" + change.NewPath
+		case before != after:
+			change.Status = FileModified
+		default:
+			continue
+		}
+		if file.text {
+			change.Diff = unifiedDiff(fromFile, toFile, before, after)
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+// unifiedDiff returns the differences between two versions of a note in unified
+// diff format with three lines of context.
+func unifiedDiff(fromFile, toFile, before, after string) string {
+	if before == after {
+		return ""
+	}
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitDiffLines(before),
+		B:        splitDiffLines(after),
+		FromFile: fromFile,
+		ToFile:   toFile,
+		Context:  3,
+	})
+	if err != nil {
+		return ""
+	}
+	return diff
+}
+
+// splitDiffLines splits content into lines that keep their line endings. A last
+// line without a newline is marked the way diff does.
+func splitDiffLines(content string) []string {
+	if content == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(content, "\n")
+	if lines[len(lines)-1] == "" {
+		return lines[:len(lines)-1]
+	}
+	lines[len(lines)-1] += "\n\\ No newline at end of file\n"
+	return lines
+}
+
+// recordingFiles reads notes with the recorded writes applied and records writes.
+type recordingFiles struct {
+	recorder *RecordingNote
+	idx      *VaultIndex
+}
+
+func (f recordingFiles) readNote(relPath string) ([]byte, error) {
+	path := filepath.Join(f.idx.VaultPath, relPath)
+	if file, ok := f.recorder.files[path]; ok {
+		return []byte(file.after), nil
+	}
+	if _, err := os.Stat(path); err != nil {
+		return nil, ErrVaultAccess
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, ErrVaultRead
+	}
+	return content, nil
+}
+
+func (f recordingFiles) writeNote(relPath string, content []byte) error {
+	file := f.recorder.file(filepath.Join(f.idx.VaultPath, relPath))
+	file.after = string(matchTextFormat([]byte(file.after), content))
+	return nil
+}
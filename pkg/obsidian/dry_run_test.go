@@ -0,0 +1,111 @@
+package obsidian_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordingNote(t *testing.T) {
+	t.Run("Records a move with its link updates without writing", func(t *testing.T) {
+		// Arrange
+		vaultDir := t.TempDir()
+		writeVaultFiles(t, vaultDir, map[string]string{
+			"old.md":   "content\n",
+			"index.md": "# Index\nSee [[old]]\n",
+		})
+		recorder := obsidian.NewRecordingNote()
+
+		// Act
+		err := recorder.Move(filepath.Join(vaultDir, "old"), filepath.Join(vaultDir, "new"))
+		assert.NoError(t, err)
+		err = recorder.UpdateLinks(vaultDir, "old", "new")
+		assert.NoError(t, err)
+
+		// Assert
+		assert.Equal(t, []obsidian.FileChange{
+			{Path: "old.md", NewPath: "new.md", Status: obsidian.FileMoved},
+			{Path: "index.md", Status: obsidian.FileModified, Diff: "--- a/index.md\n+++ b/index.md\n@@ -1,2 +1,2 @@\n # Index\n-See [[old]]\n+See [[new]]\n"},
+		}, recorder.Changes(vaultDir))
+		assert.FileExists(t, filepath.Join(vaultDir, "old.md"))
+		assert.NoFileExists(t, filepath.Join(vaultDir, "new.md"))
+		content, _ := os.ReadFile(filepath.Join(vaultDir, "index.md"))
+		assert.Equal(t, "# Index\nSee [[old]]\n", string(content))
+	})
+
+	t.Run("Later reads and writes see recorded changes", func(t *testing.T) {
+		// Arrange
+		vaultDir := t.TempDir()
+		writeVaultFiles(t, vaultDir, map[string]string{"note.md": "one\n"})
+		recorder := obsidian.NewRecordingNote()
+
+		// Act
+		err := recorder.SetContents(vaultDir, "note", "one\ntwo\n")
+		assert.NoError(t, err)
+		contents, err := recorder.GetContents(vaultDir, "note")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "one\ntwo\n", contents)
+		assert.Equal(t, "--- a/note.md\n+++ b/note.md\n@@ -1 +1,2 @@\n one\n+two\n", recorder.Changes(vaultDir)[0].Diff)
+	})
+
+	t.Run("Records created and deleted notes", func(t *testing.T) {
+		// Arrange
+		vaultDir := t.TempDir()
+		writeVaultFiles(t, vaultDir, map[string]string{"gone.md": "bye\n"})
+		recorder := obsidian.NewRecordingNote()
+
+		// Act
+		assert.NoError(t, recorder.Create(filepath.Join(vaultDir, "folder", "new.md"), "hi", false))
+		assert.NoError(t, recorder.Delete(filepath.Join(vaultDir, "gone")))
+
+		// Assert
+		assert.Equal(t, []obsidian.FileChange{
+			{Path: "folder/new.md", Status: obsidian.FileCreated, Diff: "--- /dev/null\n+++ b/folder/new.md\n@@ -0,0 +1 @@\n+hi\n\\ No newline at end of file\n"},
+			{Path: "gone.md", Status: obsidian.FileDeleted, Diff: "--- a/gone.md\n+++ /dev/null\n@@ -1 +0,0 @@\n-bye\n"},
+		}, recorder.Changes(vaultDir))
+		assert.NoDirExists(t, filepath.Join(vaultDir, "folder"))
+		assert.FileExists(t, filepath.Join(vaultDir, "gone.md"))
+	})
+
+	t.Run("Fails like a real move", func(t *testing.T) {
+		// Arrange
+		vaultDir := t.TempDir()
+		writeVaultFiles(t, vaultDir, map[string]string{"note.md": ""})
+		recorder := obsidian.NewRecordingNote()
+
+		// Act
+		missingErr := recorder.Move(filepath.Join(vaultDir, "missing"), filepath.Join(vaultDir, "new"))
+		folderErr := recorder.Move(filepath.Join(vaultDir, "note"), filepath.Join(vaultDir, "no-folder", "note"))
+
+		// Assert
+		assert.ErrorIs(t, missingErr, obsidian.ErrNoteNotFound)
+		assert.ErrorIs(t, folderErr, obsidian.ErrNoteNotFound)
+		assert.Empty(t, recorder.Changes(vaultDir))
+	})
+
+	t.Run("Records every file of a moved folder", func(t *testing.T) {
+		// Arrange
+		vaultDir := t.TempDir()
+		writeVaultFiles(t, vaultDir, map[string]string{
+			"old/a.md":    "",
+			"old/img.png": "png",
+			"index.md":    "[[old/a]]",
+		})
+		recorder := obsidian.NewRecordingNote()
+
+		// Act
+		assert.NoError(t, recorder.MoveFolder(filepath.Join(vaultDir, "old"), filepath.Join(vaultDir, "new")))
+		assert.NoError(t, recorder.UpdateFolderLinks(vaultDir, "old", "new"))
+
+		// Assert
+		changes := recorder.Changes(vaultDir)
+		assert.Equal(t, []string{"old/a.md", "old/img.png", "index.md"}, []string{changes[0].Path, changes[1].Path, changes[2].Path})
+		assert.Equal(t, "new/img.png", changes[1].NewPath)
+		assert.DirExists(t, filepath.Join(vaultDir, "old"))
+	})
+}
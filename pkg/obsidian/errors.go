@@ -0,0 +1,241 @@
+package obsidian
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/config"
+	"github.com/Yakitrak/obsidian-cli/pkg/frontmatter"
+)
+
+// Sentinel errors returned by the obsidian package. They keep the messages of the
+// matching constants, so compare them with errors.Is rather than by text.
+var (
+	ErrNoteNotFound              = errors.New(NoteDoesNotExistError)
+	ErrVaultNotFound             = errors.New(ObsidianConfigVaultNotFoundError)
+	ErrVaultFolderNotFound       = errors.New(VaultFolderNotFoundError)
+	ErrVaultNotRegistered        = errors.New(VaultNotRegisteredError)
+	ErrConfigNotFound            = errors.New(ObsidianCLIConfigReadError)
+	ErrObsidianConfigNotFound    = errors.New(ObsidianConfigReadError)
+	ErrDailyPatternNotConfigured = errors.New(ObsidianCLIDailyPatternNotConfigured)
+	ErrVaultAccess               = errors.New(VaultAccessError)
+	ErrVaultRead                 = errors.New(VaultReadError)
+	ErrWrite                     = errors.New(VaultWriteError)
+	ErrInvalidArgument           = errors.New(InvalidArgumentError)
+)
+
+// AmbiguousNoteError is returned when a note name matches more than one note.
+type AmbiguousNoteError struct {
+	Name       string
+	Candidates []string
+}
+
+func (e *AmbiguousNoteError) Error() string {
+	return fmt.Sprintf("Note name %q is ambiguous, it matches: %s", e.Name, strings.Join(e.Candidates, ", "))
+}
+
+// AmbiguousVaultError is returned when a vault name matches more than one vault
+// in Obsidian's config.
+type AmbiguousVaultError struct {
+	Name       string
+	Candidates []string
+}
+
+func (e *AmbiguousVaultError) Error() string {
+	return fmt.Sprintf("Vault name %q is ambiguous, it matches: %s; use the vault ID or path instead", e.Name, strings.Join(e.Candidates, ", "))
+}
+
+// ArgumentError is returned for arguments or flags a command cannot run with,
+// such as conflicting flags or an unknown format. It matches ErrInvalidArgument
+// with errors.Is and keeps its own message.
+type ArgumentError struct {
+	message string
+}
+
+// InvalidArgument returns an *ArgumentError with a message formatted like
+// fmt.Sprintf.
+func InvalidArgument(format string, a ...interface{}) error {
+	return &ArgumentError{message: fmt.Sprintf(format, a...)}
+}
+
+func (e *ArgumentError) Error() string {
+	return e.message
+}
+
+func (e *ArgumentError) Is(target error) bool {
+	return target == ErrInvalidArgument
+}
+
+// WriteError is returned when a note, index or config file cannot be written.
+// It matches ErrWrite with errors.Is and unwraps to the underlying file system
+// error, so callers can tell a permission problem from other failures.
+type WriteError struct {
+	Path string
+	Err  error
+
+	message string
+}
+
+func newWriteError(message, path string, err error) *WriteError {
+	return &WriteError{Path: path, Err: err, message: message}
+}
+
+func (e *WriteError) Error() string {
+	if e.message == "" {
+		return VaultWriteError
+	}
+	return e.message
+}
+
+func (e *WriteError) Unwrap() error {
+	return e.Err
+}
+
+func (e *WriteError) Is(target error) bool {
+	return target == ErrWrite
+}
+
+// Error codes reported with structured (--output json) errors. They are part of
+// the CLI interface: scripts may match on them, so existing codes must not change.
+const (
+	ErrorCodeNoteNotFound       = "note_not_found"
+	ErrorCodeAmbiguousNote      = "ambiguous_note"
+	ErrorCodeAmbiguousVault     = "ambiguous_vault"
+	ErrorCodeVaultAccess        = "vault_access"
+	ErrorCodeVaultRead          = "vault_read"
+	ErrorCodeVaultWrite         = "vault_write"
+	ErrorCodeVaultNotFound      = "vault_not_found"
+	ErrorCodeVaultNotRegistered = "vault_not_registered"
+	ErrorCodePermissionDenied   = "permission_denied"
+	ErrorCodeConfigRead         = "config_read"
+	ErrorCodeConfigParse        = "config_parse"
+	ErrorCodeConfigWrite        = "config_write"
+	ErrorCodeDailyNotConfigured = "daily_pattern_not_configured"
+	ErrorCodeUriExecute         = "uri_execute"
+	ErrorCodeIndexWrite         = "index_write"
+	ErrorCodeInvalidQuery       = "invalid_query"
+	ErrorCodeInvalidRegex       = "invalid_regex"
+	ErrorCodePathTraversal      = "path_traversal"
+	ErrorCodeInvalidFrontmatter = "invalid_frontmatter"
+	ErrorCodeNoFrontmatter      = "no_frontmatter"
+	ErrorCodeInvalidArgument    = "invalid_argument"
+	ErrorCodeUnknown            = "error"
+)
+
+// Exit codes of the CLI. Like the error codes they are documented and stable.
+const (
+	ExitCodeError            = 1
+	ExitCodeUsage            = 2
+	ExitCodeNoteNotFound     = 3
+	ExitCodeAmbiguousNote    = 4
+	ExitCodeVaultNotFound    = 5
+	ExitCodeConfigMissing    = 6
+	ExitCodePathTraversal    = 7
+	ExitCodeWriteFailed      = 8
+	ExitCodePermissionDenied = 9
+	ExitCodeAmbiguousVault   = 10
+)
+
+// errorCodes maps the message an error starts with to its code, for errors
+// that are not one of the sentinel or typed errors above.
+var errorCodes = []struct {
+	message string
+	code    string
+}{
+	{NoteDoesNotExistError, ErrorCodeNoteNotFound},
+	{VaultAccessError, ErrorCodeVaultAccess},
+	{VaultReadError, ErrorCodeVaultRead},
+	{VaultWriteError, ErrorCodeVaultWrite},
+	{ObsidianConfigVaultNotFoundError, ErrorCodeVaultNotFound},
+	{ObsidianCLIConfigReadError, ErrorCodeConfigRead},
+	{ObsidianConfigReadError, ErrorCodeConfigRead},
+	{config.UserConfigDirectoryNotFoundErrorMessage, ErrorCodeConfigRead},
+	{ObsidianCLIConfigParseError, ErrorCodeConfigParse},
+	{ObsidianConfigParseError, ErrorCodeConfigParse},
+	{ObsidianCLIConfigDirWriteEror, ErrorCodeConfigWrite},
+	{ObsidianCLIConfigGenerateJSONError, ErrorCodeConfigWrite},
+	{ObsidianCLIConfigWriteError, ErrorCodeConfigWrite},
+	{ObsidianConfigWriteError, ErrorCodeConfigWrite},
+	{ObsidianCLIDailyPatternNotConfigured, ErrorCodeDailyNotConfigured},
+	{ExecuteUriError, ErrorCodeUriExecute},
+	{IndexWriteError, ErrorCodeIndexWrite},
+	{SearchQueryParseError, ErrorCodeInvalidQuery},
+	{SearchRegexError, ErrorCodeInvalidRegex},
+	{frontmatter.InvalidFrontmatterError, ErrorCodeInvalidFrontmatter},
+	{frontmatter.NoFrontmatterError, ErrorCodeNoFrontmatter},
+}
+
+// ErrorCode returns the stable code identifying the kind of error, or
+// ErrorCodeUnknown for errors the CLI does not classify.
+func ErrorCode(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	var ambiguous *AmbiguousNoteError
+	var ambiguousVault *AmbiguousVaultError
+	switch {
+	case errors.As(err, &ambiguous):
+		return ErrorCodeAmbiguousNote
+	case errors.As(err, &ambiguousVault):
+		return ErrorCodeAmbiguousVault
+	case errors.Is(err, ErrInvalidArgument):
+		return ErrorCodeInvalidArgument
+	case errors.Is(err, ErrPathTraversal):
+		return ErrorCodePathTraversal
+	case errors.Is(err, fs.ErrPermission):
+		return ErrorCodePermissionDenied
+	case errors.Is(err, ErrNoteNotFound):
+		return ErrorCodeNoteNotFound
+	case errors.Is(err, ErrVaultNotFound), errors.Is(err, ErrVaultFolderNotFound):
+		return ErrorCodeVaultNotFound
+	case errors.Is(err, ErrVaultNotRegistered):
+		return ErrorCodeVaultNotRegistered
+	case errors.Is(err, ErrConfigNotFound), errors.Is(err, ErrObsidianConfigNotFound):
+		return ErrorCodeConfigRead
+	}
+
+	message := err.Error()
+	for _, known := range errorCodes {
+		if strings.HasPrefix(message, known.message) {
+			return known.code
+		}
+	}
+	return ErrorCodeUnknown
+}
+
+// ExitCode returns the process exit code for an error returned by a command.
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+
+	var ambiguous *AmbiguousNoteError
+	var ambiguousVault *AmbiguousVaultError
+	switch {
+	case errors.As(err, &ambiguous):
+		return ExitCodeAmbiguousNote
+	case errors.As(err, &ambiguousVault):
+		return ExitCodeAmbiguousVault
+	case errors.Is(err, ErrPathTraversal):
+		return ExitCodePathTraversal
+	case errors.Is(err, fs.ErrPermission):
+		return ExitCodePermissionDenied
+	case errors.Is(err, ErrNoteNotFound):
+		return ExitCodeNoteNotFound
+	case errors.Is(err, ErrVaultNotFound), errors.Is(err, ErrVaultFolderNotFound), errors.Is(err, ErrVaultNotRegistered):
+		return ExitCodeVaultNotFound
+	case errors.Is(err, ErrConfigNotFound), errors.Is(err, ErrObsidianConfigNotFound), errors.Is(err, ErrDailyPatternNotConfigured):
+		return ExitCodeConfigMissing
+	case errors.Is(err, ErrWrite):
+		return ExitCodeWriteFailed
+	}
+
+	switch ErrorCode(err) {
+	case ErrorCodeInvalidArgument, ErrorCodeInvalidQuery, ErrorCodeInvalidRegex:
+		return ExitCodeUsage
+	}
+	return ExitCodeError
+}
@@ -0,0 +1,101 @@
+package obsidian_test
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"testing"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{"nil error", nil, ""},
+		{"note not found", errors.New(obsidian.NoteDoesNotExistError), obsidian.ErrorCodeNoteNotFound},
+		{"wrapped message keeps its code", fmt.Errorf("%s\nYou can create today's daily note", obsidian.NoteDoesNotExistError), obsidian.ErrorCodeNoteNotFound},
+		{"vault write", errors.New(obsidian.VaultWriteError), obsidian.ErrorCodeVaultWrite},
+		{"vault not configured", errors.New(obsidian.ObsidianCLIConfigReadError), obsidian.ErrorCodeConfigRead},
+		{"query parse", fmt.Errorf("%s: missing closing quote", obsidian.SearchQueryParseError), obsidian.ErrorCodeInvalidQuery},
+		{"path traversal", fmt.Errorf("move: %w", obsidian.ErrPathTraversal), obsidian.ErrorCodePathTraversal},
+		{"vault folder not found", fmt.Errorf("%w: notes", obsidian.ErrVaultFolderNotFound), obsidian.ErrorCodeVaultNotFound},
+		{"vault not registered", obsidian.ErrVaultNotRegistered, obsidian.ErrorCodeVaultNotRegistered},
+		{"invalid argument", obsidian.InvalidArgument("Invalid format: %s", "svg"), obsidian.ErrorCodeInvalidArgument},
+		{"unknown", errors.New("something else"), obsidian.ErrorCodeUnknown},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Act
+			code := obsidian.ErrorCode(test.err)
+
+			// Assert
+			assert.Equal(t, test.expected, code)
+		})
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{"nil error", nil, 0},
+		{"note not found", obsidian.ErrNoteNotFound, obsidian.ExitCodeNoteNotFound},
+		{"wrapped note not found", fmt.Errorf("%w\nYou can create today's daily note", obsidian.ErrNoteNotFound), obsidian.ExitCodeNoteNotFound},
+		{"ambiguous note", &obsidian.AmbiguousNoteError{Name: "note", Candidates: []string{"a/note.md", "b/note.md"}}, obsidian.ExitCodeAmbiguousNote},
+		{"ambiguous vault", &obsidian.AmbiguousVaultError{Name: "notes", Candidates: []string{"/a/notes", "/b/notes"}}, obsidian.ExitCodeAmbiguousVault},
+		{"vault not found", obsidian.ErrVaultNotFound, obsidian.ExitCodeVaultNotFound},
+		{"vault folder not found", fmt.Errorf("%w: notes", obsidian.ErrVaultFolderNotFound), obsidian.ExitCodeVaultNotFound},
+		{"vault not registered", obsidian.ErrVaultNotRegistered, obsidian.ExitCodeVaultNotFound},
+		{"default vault not configured", obsidian.ErrConfigNotFound, obsidian.ExitCodeConfigMissing},
+		{"obsidian config missing", obsidian.ErrObsidianConfigNotFound, obsidian.ExitCodeConfigMissing},
+		{"daily pattern not configured", obsidian.ErrDailyPatternNotConfigured, obsidian.ExitCodeConfigMissing},
+		{"path traversal", fmt.Errorf("move: %w", obsidian.ErrPathTraversal), obsidian.ExitCodePathTraversal},
+		{"write failure", &obsidian.WriteError{Path: "note.md", Err: errors.New("disk full")}, obsidian.ExitCodeWriteFailed},
+		{"permission denied", &obsidian.WriteError{Path: "note.md", Err: fs.ErrPermission}, obsidian.ExitCodePermissionDenied},
+		{"invalid argument", obsidian.InvalidArgument("--open cannot be used with --folder"), obsidian.ExitCodeUsage},
+		{"invalid query", fmt.Errorf("%s: missing closing quote", obsidian.SearchQueryParseError), obsidian.ExitCodeUsage},
+		{"unknown", errors.New("something else"), obsidian.ExitCodeError},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Act
+			code := obsidian.ExitCode(test.err)
+
+			// Assert
+			assert.Equal(t, test.expected, code)
+		})
+	}
+}
+
+func TestWriteError(t *testing.T) {
+	// Arrange
+	cause := fs.ErrPermission
+	err := error(&obsidian.WriteError{Path: "note.md", Err: cause})
+
+	// Assert
+	assert.True(t, errors.Is(err, obsidian.ErrWrite))
+	assert.True(t, errors.Is(err, fs.ErrPermission))
+	assert.Equal(t, obsidian.VaultWriteError, err.Error())
+	var writeErr *obsidian.WriteError
+	assert.True(t, errors.As(err, &writeErr))
+	assert.Equal(t, "note.md", writeErr.Path)
+}
+
+func TestAmbiguousNoteError(t *testing.T) {
+	// Arrange
+	err := error(&obsidian.AmbiguousNoteError{Name: "note", Candidates: []string{"a/note.md", "b/note.md"}})
+
+	// Assert
+	assert.Equal(t, `Note name "note" is ambiguous, it matches: a/note.md, b/note.md`, err.Error())
+	assert.Equal(t, obsidian.ErrorCodeAmbiguousNote, obsidian.ErrorCode(err))
+	assert.False(t, errors.Is(err, obsidian.ErrNoteNotFound))
+}
@@ -0,0 +1,9 @@
+package obsidian
+
+// ForgetIndexes drops the indexes cached in memory, so the next LoadIndex reads
+// the persisted index like a new run of the CLI.
+func ForgetIndexes() {
+	indexCacheMutex.Lock()
+	defer indexCacheMutex.Unlock()
+	indexCache = make(map[string]*VaultIndex)
+}
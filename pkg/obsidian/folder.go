@@ -0,0 +1,316 @@
+package obsidian
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// MoveFolder renames a folder, creating the parent folders of the new location
+// as needed. It fails if the new location already exists.
+func (m *Note) MoveFolder(originalPath string, newPath string) error {
+	if err := checkFolderMove(originalPath, newPath); err != nil {
+		return err
+	}
+
+	return m.Transact(func() error {
+		if err := m.tx.MkdirAll(filepath.Dir(newPath)); err != nil {
+			return err
+		}
+		if err := m.tx.Rename(originalPath, newPath); err != nil {
+			return newWriteError(VaultWriteError, newPath, err)
+		}
+		return nil
+	})
+}
+
+// checkFolderMove returns an error if the folder does not exist or the new location is taken.
+func checkFolderMove(originalPath string, newPath string) error {
+	info, err := os.Stat(originalPath)
+	if err != nil || !info.IsDir() {
+		return fmt.Errorf("Cannot find folder %s in vault", originalPath)
+	}
+	if _, err := os.Stat(newPath); err == nil {
+		return fmt.Errorf("Folder %s already exists", newPath)
+	}
+	return nil
+}
+
+// UpdateFolderLinks rewrites the links that a move of a folder, given by
+// vault-relative paths, breaks, in a single pass over the vault. It works the
+// same before and after the folder is moved on disk. Links are resolved the way
+// Obsidian resolves them in the note they are written in:
+//   - links written with the vault path of a note or attachment inside the folder
+//     point to its new path,
+//   - relative markdown links are written again relative to the new location of
+//     their target and of the note they are in, so links inside the folder keep
+//     working when its depth changes,
+//   - path suffixes that no longer match become vault paths.
+//
+// Links by file name keep working on their own.
+func (m *Note) UpdateFolderLinks(vaultPath string, oldFolder string, newFolder string) error {
+	idx, err := LoadIndex(vaultPath)
+	if err != nil {
+		return err
+	}
+
+	return m.Transact(func() error {
+		if err := updateFolderLinks(idx, diskFiles{idx, m.tx}, oldFolder, newFolder); err != nil {
+			return err
+		}
+		m.tx.OnCommit(func() { _ = idx.Save() })
+		return nil
+	})
+}
+
+func updateFolderLinks(idx *VaultIndex, files noteFiles, oldFolder string, newFolder string) error {
+	move := newFolderMove(idx, oldFolder, newFolder)
+	for _, relPath := range idx.order {
+		if !move.affects(relPath, idx.Entries[relPath]) {
+			continue
+		}
+
+		originalContent, err := files.readNote(relPath)
+		if err != nil {
+			return err
+		}
+
+		updatedContent := replaceLinks(originalContent, func(link Link, text string) string {
+			if target, ok := move.newTarget(relPath, link); ok {
+				return withLinkTarget(link, text, target)
+			}
+			return text
+		})
+		if bytes.Equal(updatedContent, originalContent) {
+			continue
+		}
+		if err := files.writeNote(relPath, updatedContent); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// folderLinkPrefix returns the folder as it starts link targets: forward slashes
+// and a trailing slash, so "notes" does not match "notes-archive".
+func folderLinkPrefix(folder string) string {
+	folder = strings.Trim(normalizePathSeparators(filepath.Clean(folder)), "/")
+	return folder + "/"
+}
+
+// folderMove resolves links as they were before a folder was moved. The index
+// may list the notes of the folder at either location, depending on whether the
+// folder was already moved on disk.
+type folderMove struct {
+	oldPrefix string
+	newPrefix string
+	// paths maps the folded vault paths of the notes and attachments, as they were
+	// before the move, to the paths.
+	paths map[string]string
+	// moved lists the paths inside the folder, before the move.
+	moved []string
+}
+
+func newFolderMove(idx *VaultIndex, oldFolder string, newFolder string) *folderMove {
+	move := &folderMove{
+		oldPrefix: folderLinkPrefix(oldFolder),
+		newPrefix: folderLinkPrefix(newFolder),
+		paths:     make(map[string]string),
+	}
+	add := func(relPath string) {
+		oldPath := move.oldPath(normalizePathSeparators(relPath))
+		move.paths[foldName(oldPath)] = oldPath
+		if strings.HasPrefix(oldPath, move.oldPrefix) {
+			move.moved = append(move.moved, oldPath)
+		}
+	}
+	for relPath := range idx.Entries {
+		add(relPath)
+	}
+	lookup := idx.noteLookup()
+	if lookup.attachments == nil {
+		lookup.attachments = walkVaultAttachments(idx.VaultPath)
+	}
+	for _, relPaths := range lookup.attachments {
+		for _, relPath := range relPaths {
+			add(relPath)
+		}
+	}
+	return move
+}
+
+// oldPath returns where a file was before the move.
+func (f *folderMove) oldPath(p string) string {
+	if strings.HasPrefix(p, f.newPrefix) {
+		return f.oldPrefix + strings.TrimPrefix(p, f.newPrefix)
+	}
+	return p
+}
+
+// newPath returns where a file is after the move.
+func (f *folderMove) newPath(p string) string {
+	if strings.HasPrefix(p, f.oldPrefix) {
+		return f.newPrefix + strings.TrimPrefix(p, f.oldPrefix)
+	}
+	return p
+}
+
+// affects reports whether the note at relPath has links the move breaks. Notes
+// whose links are not indexed are always included.
+func (f *folderMove) affects(relPath string, entry *IndexEntry) bool {
+	if entry.unparsed() {
+		return true
+	}
+	for _, link := range entry.Links {
+		if _, ok := f.newTarget(relPath, link); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// newTarget returns the target a link in the note at relPath must be written
+// with after the move, or false if the link keeps working as it is.
+func (f *folderMove) newTarget(relPath string, link Link) (string, bool) {
+	written := normalizePathSeparators(link.Target)
+	if written == "" {
+		return "", false
+	}
+	source := f.oldPath(normalizePathSeparators(relPath))
+	target := strings.TrimPrefix(written, "./")
+
+	var newTarget string
+	switch {
+	case link.Kind == MarkdownLinkKind && !strings.HasPrefix(target, "/") && f.exists(path.Join(path.Dir(source), target)):
+		oldTarget := f.paths[f.key(path.Join(path.Dir(source), target))]
+		newSource := f.newPath(source)
+		if oldTarget == f.newPath(oldTarget) && source == newSource {
+			return "", false
+		}
+		newTarget = relativeLinkPath(path.Dir(newSource), f.newPath(oldTarget))
+		if strings.HasPrefix(written, "./") && !strings.HasPrefix(newTarget, "../") {
+			newTarget = "./" + newTarget
+		}
+	case f.exists(strings.TrimPrefix(target, "/")):
+		oldTarget := f.paths[f.key(strings.TrimPrefix(target, "/"))]
+		if !strings.HasPrefix(oldTarget, f.oldPrefix) {
+			return "", false
+		}
+		newTarget = f.newPath(oldTarget)
+		if strings.HasPrefix(target, "/") {
+			newTarget = "/" + newTarget
+		}
+	case f.inFolder(strings.TrimPrefix(target, "/")):
+		// A link into the folder to a file that does not exist keeps pointing
+		// into the folder
+		trimmed := strings.TrimPrefix(target, "/")
+		newTarget = strings.TrimSuffix(target, trimmed) + f.newPrefix + trimmed[len(f.oldPrefix):]
+	case strings.Contains(target, "/"):
+		oldTarget, ok := f.suffixMatch(target)
+		if !ok || f.matchesSuffix(f.newPath(oldTarget), target) {
+			return "", false
+		}
+		newTarget = f.newPath(oldTarget)
+	default:
+		return "", false
+	}
+
+	if !strings.HasSuffix(strings.ToLower(written), ".md") {
+		newTarget = strings.TrimSuffix(newTarget, ".md")
+	}
+	return newTarget, newTarget != written
+}
+
+// inFolder reports whether a vault path written in a link starts with the folder,
+// ignoring case like Obsidian.
+func (f *folderMove) inFolder(p string) bool {
+	return len(p) >= len(f.oldPrefix) && strings.EqualFold(p[:len(f.oldPrefix)], f.oldPrefix)
+}
+
+// key returns the key of a path in paths, trying the path as a note if no file
+// has that exact path.
+func (f *folderMove) key(p string) string {
+	key := foldName(path.Clean(p))
+	if _, ok := f.paths[key]; !ok {
+		return foldName(AddMdSuffix(path.Clean(p)))
+	}
+	return key
+}
+
+func (f *folderMove) exists(p string) bool {
+	if strings.HasPrefix(path.Clean(p), "../") {
+		return false
+	}
+	_, ok := f.paths[f.key(p)]
+	return ok
+}
+
+// suffixMatch finds the single file inside the folder whose path ends with target.
+func (f *folderMove) suffixMatch(target string) (string, bool) {
+	var match string
+	for _, p := range f.moved {
+		if f.matchesSuffix(p, target) {
+			if match != "" {
+				return "", false
+			}
+			match = p
+		}
+	}
+	return match, match != ""
+}
+
+func (f *folderMove) matchesSuffix(p string, target string) bool {
+	file := foldName(RemoveMdSuffix(p))
+	suffix := foldName(RemoveMdSuffix(target))
+	return file == suffix || strings.HasSuffix(file, "/"+suffix)
+}
+
+// relativeLinkPath returns the path of a file relative to a folder, both given
+// as vault paths with forward slashes.
+func relativeLinkPath(dir string, file string) string {
+	rel, err := filepath.Rel(filepath.FromSlash(dir), filepath.FromSlash(file))
+	if err != nil {
+		return file
+	}
+	return filepath.ToSlash(rel)
+}
+
+// withLinkTarget returns the text of a link with its target replaced, keeping
+// its heading or block, alias, title and the way it is written.
+func withLinkTarget(link Link, text string, target string) string {
+	if link.Kind == WikiLinkKind {
+		open := strings.Index(text, "[[") + 2
+		end := open + strings.IndexAny(text[open:], "#^|]")
+		if text[end] == '|' && end > open && text[end-1] == '\\' {
+			// Keep the escape of a link inside a table
+			end--
+		}
+		return text[:open] + target + text[end:]
+	}
+
+	open := strings.Index(text, "](") + 2
+	destination := text[open : len(text)-1]
+	leading := len(destination) - len(strings.TrimLeft(destination, " \t"))
+	start := open + leading
+	if strings.HasPrefix(destination[leading:], "<") {
+		start++
+	} else {
+		target = escapeLinkPath(target)
+	}
+	end := start + strings.IndexAny(text[start:]+">", "#^> \t)")
+	return text[:start] + target + text[end:]
+}
+
+// escapeLinkPath percent-encodes each folder and file name of a markdown link
+// destination, so names with spaces, parentheses, # or % keep working.
+func escapeLinkPath(p string) string {
+	parts := strings.Split(p, "/")
+	for i, part := range parts {
+		parts[i] = url.PathEscape(part)
+	}
+	return strings.Join(parts, "/")
+}
@@ -0,0 +1,191 @@
+package obsidian_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNote_MoveFolder(t *testing.T) {
+	t.Run("Moves the folder and creates parent folders", func(t *testing.T) {
+		// Arrange
+		vaultDir := t.TempDir()
+		writeVaultFiles(t, vaultDir, map[string]string{"old/sub/note.md": "content"})
+		noteManager := obsidian.Note{}
+
+		// Act
+		err := noteManager.MoveFolder(filepath.Join(vaultDir, "old"), filepath.Join(vaultDir, "a", "b", "new"))
+
+		// Assert
+		assert.NoError(t, err)
+		assert.FileExists(t, filepath.Join(vaultDir, "a", "b", "new", "sub", "note.md"))
+		assert.NoDirExists(t, filepath.Join(vaultDir, "old"))
+	})
+
+	t.Run("Folder does not exist", func(t *testing.T) {
+		// Arrange
+		vaultDir := t.TempDir()
+		noteManager := obsidian.Note{}
+
+		// Act
+		err := noteManager.MoveFolder(filepath.Join(vaultDir, "missing"), filepath.Join(vaultDir, "new"))
+
+		// Assert
+		assert.Error(t, err)
+	})
+
+	t.Run("New location already exists", func(t *testing.T) {
+		// Arrange
+		vaultDir := t.TempDir()
+		writeVaultFiles(t, vaultDir, map[string]string{"old/a.md": "", "new/b.md": ""})
+		noteManager := obsidian.Note{}
+
+		// Act
+		err := noteManager.MoveFolder(filepath.Join(vaultDir, "old"), filepath.Join(vaultDir, "new"))
+
+		// Assert
+		assert.Error(t, err)
+		assert.FileExists(t, filepath.Join(vaultDir, "old", "a.md"))
+	})
+}
+
+func TestNote_UpdateFolderLinks(t *testing.T) {
+	// Arrange
+	vaultDir := t.TempDir()
+	writeVaultFiles(t, vaultDir, map[string]string{
+		"index.md": "[[old/a]] [[old/sub/b|B]] ![[old/img.png]] [[a]] [[older/c]]\n" +
+			"[a](old/a.md) [a](./old/a.md#Part) [b](<old/sub/b.md>)",
+		"new folder/a.md":     "[[new folder/sub/b]]",
+		"new folder/sub/b.md": "",
+	})
+	noteManager := obsidian.Note{}
+
+	// Act
+	err := noteManager.UpdateFolderLinks(vaultDir, "old", "new folder")
+
+	// Assert
+	assert.NoError(t, err)
+	content, _ := os.ReadFile(filepath.Join(vaultDir, "index.md"))
+	assert.Equal(t, "[[new folder/a]] [[new folder/sub/b|B]] ![[new folder/img.png]] [[a]] [[older/c]]\n"+
+		"[a](new%20folder/a.md) [a](./new%20folder/a.md#Part) [b](<new folder/sub/b.md>)", string(content))
+	content, _ = os.ReadFile(filepath.Join(vaultDir, "new folder", "a.md"))
+	assert.Equal(t, "[[new folder/sub/b]]", string(content))
+}
+
+func TestNote_UpdateFolderLinksResolvesLinks(t *testing.T) {
+	t.Run("Relative links from outside the folder", func(t *testing.T) {
+		// Arrange
+		vaultDir := t.TempDir()
+		writeVaultFiles(t, vaultDir, map[string]string{
+			"other/index.md": "[a](../old/a.md) [b](../old/sub/b.md#Part) [c](./c.md)",
+			"other/c.md":     "",
+			"old/a.md":       "",
+			"old/sub/b.md":   "",
+		})
+		noteManager := obsidian.Note{}
+
+		// Act
+		err := noteManager.UpdateFolderLinks(vaultDir, "old", filepath.Join("archive", "2024"))
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "[a](../archive/2024/a.md) [b](../archive/2024/sub/b.md#Part) [c](./c.md)",
+			readVaultFile(t, filepath.Join(vaultDir, "other", "index.md")))
+	})
+
+	t.Run("Relative links inside the folder when its depth changes", func(t *testing.T) {
+		// Arrange
+		vaultDir := t.TempDir()
+		writeVaultFiles(t, vaultDir, map[string]string{
+			"outside.md":   "",
+			"old/a.md":     "[out](../outside.md) [b](sub/b.md) [[sub/b]]",
+			"old/sub/b.md": "[a](../a.md)",
+		})
+		noteManager := obsidian.Note{}
+
+		// Act
+		err := noteManager.MoveFolder(filepath.Join(vaultDir, "old"), filepath.Join(vaultDir, "a", "b"))
+		assert.NoError(t, err)
+		err = noteManager.UpdateFolderLinks(vaultDir, "old", filepath.Join("a", "b"))
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "[out](../../outside.md) [b](sub/b.md) [[sub/b]]", readVaultFile(t, filepath.Join(vaultDir, "a", "b", "a.md")))
+		assert.Equal(t, "[a](../a.md)", readVaultFile(t, filepath.Join(vaultDir, "a", "b", "sub", "b.md")))
+	})
+
+	t.Run("Links written in another case and path suffixes", func(t *testing.T) {
+		// Arrange
+		vaultDir := t.TempDir()
+		writeVaultFiles(t, vaultDir, map[string]string{
+			"index.md":           "[[Projects/x]] [[projects/x|X]] [[work/x]] [[x]]",
+			"projects/work/x.md": "",
+		})
+		noteManager := obsidian.Note{}
+
+		// Act
+		err := noteManager.UpdateFolderLinks(vaultDir, filepath.Join("projects", "work"), "done")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "[[Projects/x]] [[projects/x|X]] [[done/x]] [[x]]", readVaultFile(t, filepath.Join(vaultDir, "index.md")))
+	})
+
+	t.Run("Links in another case are rewritten", func(t *testing.T) {
+		// Arrange
+		vaultDir := t.TempDir()
+		writeVaultFiles(t, vaultDir, map[string]string{
+			"index.md":      "[[Projects/x]] [x](PROJECTS/x.md)\n`[[projects/x]]`\n```\n[[projects/x]]\n```\n",
+			"projects/x.md": "",
+		})
+		noteManager := obsidian.Note{}
+
+		// Act
+		err := noteManager.UpdateFolderLinks(vaultDir, "projects", "archive")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "[[archive/x]] [x](archive/x.md)\n`[[projects/x]]`\n```\n[[projects/x]]\n```\n",
+			readVaultFile(t, filepath.Join(vaultDir, "index.md")))
+	})
+
+	t.Run("Markdown links to folders with special characters are escaped", func(t *testing.T) {
+		// Arrange
+		vaultDir := t.TempDir()
+		writeVaultFiles(t, vaultDir, map[string]string{
+			"index.md": "[a](old/a.md) [b](<old/b.md>)",
+			"old/a.md": "",
+			"old/b.md": "",
+		})
+		noteManager := obsidian.Note{}
+
+		// Act
+		err := noteManager.UpdateFolderLinks(vaultDir, "old", "Q1 (50% #done)")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "[a](Q1%20%2850%25%20%23done%29/a.md) [b](<Q1 (50% #done)/b.md>)",
+			readVaultFile(t, filepath.Join(vaultDir, "index.md")))
+	})
+
+	t.Run("Links in a table keep their escaped alias", func(t *testing.T) {
+		// Arrange
+		vaultDir := t.TempDir()
+		writeVaultFiles(t, vaultDir, map[string]string{
+			"index.md": "| Note | Done |\n| --- | --- |\n| [[old/A\\|alias]] | [[old/A]] |\n",
+			"old/A.md": "",
+		})
+		noteManager := obsidian.Note{}
+
+		// Act
+		err := noteManager.UpdateFolderLinks(vaultDir, "old", filepath.Join("new", "dir"))
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "| Note | Done |\n| --- | --- |\n| [[new/dir/A\\|alias]] | [[new/dir/A]] |\n",
+			readVaultFile(t, filepath.Join(vaultDir, "index.md")))
+	})
+}
@@ -21,7 +21,7 @@ func (f *FuzzyFinder) Find(slice interface{}, itemFunc func(i int) string, opts
 		return itemFunc(i)
 	})
 	if err != nil {
-		return -1, errors.New(NoteDoesNotExistError)
+		return -1, ErrNoteNotFound
 	}
 	return index, nil
 }
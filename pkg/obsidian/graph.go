@@ -0,0 +1,178 @@
+package obsidian
+
+import (
+	"path/filepath"
+)
+
+// LinkGraph is the graph of links between the notes of a vault. Only links that
+// resolve to another note are edges: links to attachments, to missing notes and
+// from a note to itself are left out.
+type LinkGraph struct {
+	// Notes holds the vault-relative paths of all notes in vault walk order.
+	Notes []string
+	// Outgoing maps a note to the notes it links to, in the order first linked.
+	Outgoing map[string][]string
+	// Incoming maps a note to the notes linking to it, in vault walk order.
+	Incoming map[string][]string
+
+	index *VaultIndex
+}
+
+// LinkGraph resolves the links of every note in a single pass over the index.
+func (idx *VaultIndex) LinkGraph() *LinkGraph {
+	graph := &LinkGraph{
+		Notes:    idx.Notes(),
+		Outgoing: make(map[string][]string, len(idx.order)),
+		Incoming: make(map[string][]string, len(idx.order)),
+		index:    idx,
+	}
+
+	for _, source := range idx.order {
+		seen := make(map[string]bool)
+		for _, link := range idx.ResolveLinks(source) {
+			if !link.Exists || link.Path == source || seen[link.Path] {
+				continue
+			}
+			if _, isNote := idx.Entries[link.Path]; !isNote {
+				continue
+			}
+			seen[link.Path] = true
+			graph.Outgoing[source] = append(graph.Outgoing[source], link.Path)
+			graph.Incoming[link.Path] = append(graph.Incoming[link.Path], source)
+		}
+	}
+	return graph
+}
+
+// Orphans returns the notes no other note links to.
+func (g *LinkGraph) Orphans() []string {
+	var orphans []string
+	for _, note := range g.Notes {
+		if len(g.Incoming[note]) == 0 {
+			orphans = append(orphans, note)
+		}
+	}
+	return orphans
+}
+
+// DeadEnds returns the notes that link to no other note.
+func (g *LinkGraph) DeadEnds() []string {
+	var deadEnds []string
+	for _, note := range g.Notes {
+		if len(g.Outgoing[note]) == 0 {
+			deadEnds = append(deadEnds, note)
+		}
+	}
+	return deadEnds
+}
+
+// Node and edge types of an exported graph.
+const (
+	NoteNode       = "note"
+	TagNode        = "tag"
+	AttachmentNode = "attachment"
+
+	LinkEdge  = "link"
+	EmbedEdge = "embed"
+	TagEdge   = "tag"
+)
+
+// GraphExportOptions selects the optional node types of an exported graph.
+type GraphExportOptions struct {
+	Tags        bool
+	Attachments bool
+}
+
+// GraphNode is a note, tag or attachment. Notes are identified by their vault-relative
+// path with forward slashes, tags by "#" and the tag name. Attributes holds the
+// frontmatter of notes.
+type GraphNode struct {
+	ID         string                 `json:"id"`
+	Type       string                 `json:"type"`
+	Label      string                 `json:"label"`
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+}
+
+// GraphEdge connects two nodes. Weight counts the links from Source to Target.
+type GraphEdge struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+	Type   string `json:"type"`
+	Weight int    `json:"weight"`
+}
+
+// GraphExport is the vault graph with typed nodes and edges, ready to be written
+// in a graph file format.
+type GraphExport struct {
+	Nodes []GraphNode `json:"nodes"`
+	Edges []GraphEdge `json:"edges"`
+}
+
+// ExportGraph builds the typed graph of the vault: a node per note with its
+// frontmatter, link and embed edges between notes and, when selected, tag and
+// attachment nodes. Links to missing notes and links of a note to itself are left out.
+func (idx *VaultIndex) ExportGraph(opts GraphExportOptions) *GraphExport {
+	export := &GraphExport{Nodes: []GraphNode{}, Edges: []GraphEdge{}}
+	nodes := make(map[string]bool)
+	addNode := func(node GraphNode) {
+		if !nodes[node.ID] {
+			nodes[node.ID] = true
+			export.Nodes = append(export.Nodes, node)
+		}
+	}
+
+	for _, relPath := range idx.order {
+		addNode(GraphNode{
+			ID:         graphNodeID(relPath),
+			Type:       NoteNode,
+			Label:      RemoveMdSuffix(filepath.Base(relPath)),
+			Attributes: idx.Entries[relPath].Frontmatter,
+		})
+	}
+
+	type edgeKey struct{ source, target, edgeType string }
+	edges := make(map[edgeKey]int)
+	addEdge := func(source, target, edgeType string) {
+		key := edgeKey{source, target, edgeType}
+		if i, ok := edges[key]; ok {
+			export.Edges[i].Weight++
+			return
+		}
+		edges[key] = len(export.Edges)
+		export.Edges = append(export.Edges, GraphEdge{Source: source, Target: target, Type: edgeType, Weight: 1})
+	}
+
+	for _, relPath := range idx.order {
+		source := graphNodeID(relPath)
+		for _, link := range idx.ResolveLinks(relPath) {
+			if !link.Exists || link.Path == relPath {
+				continue
+			}
+			target := graphNodeID(link.Path)
+			if _, isNote := idx.Entries[link.Path]; !isNote {
+				if !opts.Attachments {
+					continue
+				}
+				addNode(GraphNode{ID: target, Type: AttachmentNode, Label: filepath.Base(link.Path)})
+			}
+			edgeType := LinkEdge
+			if link.Embed {
+				edgeType = EmbedEdge
+			}
+			addEdge(source, target, edgeType)
+		}
+
+		if opts.Tags {
+			for _, tag := range idx.Entries[relPath].Tags {
+				addNode(GraphNode{ID: "#" + tag, Type: TagNode, Label: "#" + tag})
+				addEdge(source, "#"+tag, TagEdge)
+			}
+		}
+	}
+	return export
+}
+
+// graphNodeID identifies a file in an exported graph by its vault path with forward slashes.
+func graphNodeID(relPath string) string {
+	return normalizePathSeparators(relPath)
+}
@@ -0,0 +1,243 @@
+package obsidian
+
+import (
+	"math"
+	"sort"
+)
+
+const (
+	pageRankDamping    = 0.85
+	pageRankIterations = 100
+	pageRankTolerance  = 1e-9
+
+	// maxCommunityRounds bounds community detection on graphs where notes keep moving.
+	maxCommunityRounds   = 50
+	communityGainEpsilon = 1e-12
+)
+
+// NoteRank holds the link counts and PageRank of a note.
+type NoteRank struct {
+	Note      string  `json:"note"`
+	InDegree  int     `json:"in_degree"`
+	OutDegree int     `json:"out_degree"`
+	PageRank  float64 `json:"pagerank"`
+}
+
+// Lookup finds a note of the graph by vault-relative path or file name, the same
+// way notes are found for other commands.
+func (g *LinkGraph) Lookup(noteName string) (string, error) {
+	if g.index != nil {
+		return g.index.ResolveNote(noteName, "")
+	}
+	note := AddMdSuffix(noteName)
+	for _, relPath := range g.Notes {
+		if relPath == note {
+			return relPath, nil
+		}
+	}
+	return "", ErrNoteNotFound
+}
+
+// ShortestPath returns the shortest chain of links from one note to another,
+// including both ends, or nil if there is none. With undirected, links may also
+// be followed backwards.
+func (g *LinkGraph) ShortestPath(from, to string, undirected bool) []string {
+	previous := map[string]string{from: ""}
+	queue := []string{from}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if current == to {
+			var path []string
+			for note := to; note != ""; note = previous[note] {
+				path = append([]string{note}, path...)
+			}
+			return path
+		}
+		for _, next := range g.neighbours(current, undirected) {
+			if _, seen := previous[next]; !seen {
+				previous[next] = current
+				queue = append(queue, next)
+			}
+		}
+	}
+	return nil
+}
+
+func (g *LinkGraph) neighbours(note string, undirected bool) []string {
+	if !undirected {
+		return g.Outgoing[note]
+	}
+	return append(append([]string{}, g.Outgoing[note]...), g.Incoming[note]...)
+}
+
+// Ranks returns the in-degree, out-degree and PageRank of every note, in the order
+// of Notes. PageRanks sum to 1; notes without outgoing links spread their rank
+// evenly over all notes.
+func (g *LinkGraph) Ranks() []NoteRank {
+	n := len(g.Notes)
+	ranks := make([]NoteRank, n)
+	if n == 0 {
+		return ranks
+	}
+
+	position := make(map[string]int, n)
+	for i, note := range g.Notes {
+		position[note] = i
+	}
+
+	rank := make([]float64, n)
+	for i := range rank {
+		rank[i] = 1 / float64(n)
+	}
+	for iteration := 0; iteration < pageRankIterations; iteration++ {
+		next := make([]float64, n)
+		dangling := 0.0
+		for i, note := range g.Notes {
+			targets := g.Outgoing[note]
+			if len(targets) == 0 {
+				dangling += rank[i]
+				continue
+			}
+			share := rank[i] / float64(len(targets))
+			for _, target := range targets {
+				next[position[target]] += share
+			}
+		}
+
+		change := 0.0
+		for i := range next {
+			next[i] = (1-pageRankDamping)/float64(n) + pageRankDamping*(next[i]+dangling/float64(n))
+			change += math.Abs(next[i] - rank[i])
+		}
+		rank = next
+		if change < pageRankTolerance {
+			break
+		}
+	}
+
+	for i, note := range g.Notes {
+		ranks[i] = NoteRank{
+			Note:      note,
+			InDegree:  len(g.Incoming[note]),
+			OutDegree: len(g.Outgoing[note]),
+			PageRank:  rank[i],
+		}
+	}
+	return ranks
+}
+
+// Components returns the groups of notes connected by links in either direction,
+// largest first. Notes within a group keep the order of Notes.
+func (g *LinkGraph) Components() [][]string {
+	component := make(map[string]int, len(g.Notes))
+	var components [][]string
+	for _, note := range g.Notes {
+		if _, seen := component[note]; seen {
+			continue
+		}
+		id := len(components)
+		component[note] = id
+		queue := []string{note}
+		for len(queue) > 0 {
+			current := queue[0]
+			queue = queue[1:]
+			for _, next := range g.neighbours(current, true) {
+				if _, seen := component[next]; !seen {
+					component[next] = id
+					queue = append(queue, next)
+				}
+			}
+		}
+		components = append(components, nil)
+	}
+	return groupNotes(g.Notes, component, len(components))
+}
+
+// Communities splits the notes into densely linked groups. Starting with every note
+// in its own group, each note in turn moves to the neighbouring group that raises
+// the modularity of the grouping the most, until no note moves (the local moving
+// phase of the Louvain method). Links count in both directions. Notes are visited
+// in the order of Notes, so results are stable between runs. Groups are returned
+// largest first.
+func (g *LinkGraph) Communities() [][]string {
+	weights := make(map[string]map[string]float64, len(g.Notes))
+	neighbours := make(map[string][]string, len(g.Notes))
+	degree := make(map[string]float64, len(g.Notes))
+	totalWeight := 0.0
+	for _, note := range g.Notes {
+		weights[note] = make(map[string]float64)
+		for _, other := range g.neighbours(note, true) {
+			if _, seen := weights[note][other]; !seen {
+				neighbours[note] = append(neighbours[note], other)
+			}
+			weights[note][other]++
+			degree[note]++
+			totalWeight++
+		}
+	}
+
+	community := make(map[string]int, len(g.Notes))
+	communityDegree := make([]float64, len(g.Notes))
+	for i, note := range g.Notes {
+		community[note] = i
+		communityDegree[i] = degree[note]
+	}
+	if totalWeight == 0 {
+		return groupNotes(g.Notes, community, len(g.Notes))
+	}
+
+	for round := 0; round < maxCommunityRounds; round++ {
+		moved := false
+		for _, note := range g.Notes {
+			current := community[note]
+			communityDegree[current] -= degree[note]
+
+			linksTo := make(map[int]float64)
+			for _, other := range neighbours[note] {
+				linksTo[community[other]] += weights[note][other]
+			}
+			gain := func(c int) float64 {
+				return linksTo[c] - communityDegree[c]*degree[note]/totalWeight
+			}
+
+			best, bestGain := current, gain(current)
+			for _, other := range neighbours[note] {
+				if c := community[other]; gain(c) > bestGain+communityGainEpsilon {
+					best, bestGain = c, gain(c)
+				}
+			}
+
+			community[note] = best
+			communityDegree[best] += degree[note]
+			if best != current {
+				moved = true
+			}
+		}
+		if !moved {
+			break
+		}
+	}
+	return groupNotes(g.Notes, community, len(g.Notes))
+}
+
+// groupNotes collects notes by group number and sorts the groups by size, largest
+// first, keeping the order of first appearance between groups of equal size.
+func groupNotes(notes []string, group map[string]int, groupCount int) [][]string {
+	members := make([][]string, groupCount)
+	var order []int
+	for _, note := range notes {
+		id := group[note]
+		if members[id] == nil {
+			order = append(order, id)
+		}
+		members[id] = append(members[id], note)
+	}
+
+	groups := make([][]string, 0, len(order))
+	for _, id := range order {
+		groups = append(groups, members[id])
+	}
+	sort.SliceStable(groups, func(i, j int) bool { return len(groups[i]) > len(groups[j]) })
+	return groups
+}
@@ -0,0 +1,97 @@
+package obsidian_test
+
+import (
+	"testing"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/stretchr/testify/assert"
+)
+
+// newLinkGraph builds a graph from outgoing links, filling in incoming links.
+func newLinkGraph(notes []string, outgoing map[string][]string) *obsidian.LinkGraph {
+	graph := &obsidian.LinkGraph{Notes: notes, Outgoing: outgoing, Incoming: map[string][]string{}}
+	for _, source := range notes {
+		for _, target := range outgoing[source] {
+			graph.Incoming[target] = append(graph.Incoming[target], source)
+		}
+	}
+	return graph
+}
+
+func TestLinkGraph_ShortestPath(t *testing.T) {
+	graph := newLinkGraph([]string{"a.md", "b.md", "c.md", "d.md", "e.md"}, map[string][]string{
+		"a.md": {"b.md", "d.md"},
+		"b.md": {"c.md"},
+		"d.md": {"c.md", "e.md"},
+		"c.md": {"e.md"},
+	})
+
+	t.Run("Follows links forwards", func(t *testing.T) {
+		assert.Equal(t, []string{"a.md", "d.md", "e.md"}, graph.ShortestPath("a.md", "e.md", false))
+		assert.Equal(t, []string{"a.md"}, graph.ShortestPath("a.md", "a.md", false))
+		assert.Nil(t, graph.ShortestPath("e.md", "a.md", false))
+	})
+
+	t.Run("Undirected also follows links backwards", func(t *testing.T) {
+		assert.Equal(t, []string{"e.md", "d.md", "a.md"}, graph.ShortestPath("e.md", "a.md", true))
+	})
+}
+
+func TestLinkGraph_Ranks(t *testing.T) {
+	// Arrange
+	graph := newLinkGraph([]string{"hub.md", "a.md", "b.md", "c.md"}, map[string][]string{
+		"a.md": {"hub.md"},
+		"b.md": {"hub.md"},
+		"c.md": {"hub.md", "a.md"},
+	})
+
+	// Act
+	ranks := graph.Ranks()
+
+	// Assert
+	total := 0.0
+	for _, rank := range ranks {
+		total += rank.PageRank
+	}
+	assert.InDelta(t, 1.0, total, 1e-6)
+	assert.Equal(t, "hub.md", ranks[0].Note)
+	assert.Equal(t, 3, ranks[0].InDegree)
+	assert.Equal(t, 2, ranks[3].OutDegree)
+	for _, rank := range ranks[1:] {
+		assert.Greater(t, ranks[0].PageRank, rank.PageRank)
+	}
+	assert.Greater(t, ranks[1].PageRank, ranks[2].PageRank, "a.md is linked from c.md")
+}
+
+func TestLinkGraph_Components(t *testing.T) {
+	// Arrange
+	graph := newLinkGraph([]string{"a.md", "b.md", "lonely.md", "x.md", "y.md", "z.md"}, map[string][]string{
+		"a.md": {"b.md"},
+		"y.md": {"x.md"},
+		"z.md": {"x.md"},
+	})
+
+	// Act
+	components := graph.Components()
+
+	// Assert
+	assert.Equal(t, [][]string{{"x.md", "y.md", "z.md"}, {"a.md", "b.md"}, {"lonely.md"}}, components)
+}
+
+func TestLinkGraph_Communities(t *testing.T) {
+	// Arrange: two triangles joined by a single link
+	graph := newLinkGraph([]string{"a1.md", "a2.md", "a3.md", "b1.md", "b2.md", "b3.md"}, map[string][]string{
+		"a1.md": {"a2.md", "a3.md"},
+		"a2.md": {"a3.md"},
+		"a3.md": {"b1.md"},
+		"b1.md": {"b2.md", "b3.md"},
+		"b2.md": {"b3.md"},
+	})
+
+	// Act
+	communities := graph.Communities()
+
+	// Assert
+	assert.Equal(t, [][]string{{"a1.md", "a2.md", "a3.md"}, {"b1.md", "b2.md", "b3.md"}}, communities)
+	assert.Len(t, graph.Components(), 1)
+}
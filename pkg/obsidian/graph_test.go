@@ -0,0 +1,77 @@
+package obsidian_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNote_GetLinkGraph(t *testing.T) {
+	// Arrange
+	vaultDir := t.TempDir()
+	writeVaultFiles(t, vaultDir, map[string]string{
+		"a.md":        "[[b]] [[b#Heading]] [[folder/c]] [[a]] [[missing]] ![[image.png]]",
+		"b.md":        "[c](folder/c.md)",
+		"folder/c.md": "[[#Self]]\n# Self",
+		"d.md":        "![[image.png]]",
+		"image.png":   "png",
+	})
+	noteManager := obsidian.Note{}
+	c := filepath.Join("folder", "c.md")
+
+	// Act
+	graph, err := noteManager.GetLinkGraph(vaultDir)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"b.md", c}, graph.Outgoing["a.md"])
+	assert.Equal(t, []string{"a.md", "b.md"}, graph.Incoming[c])
+	assert.Empty(t, graph.Incoming["a.md"])
+	assert.ElementsMatch(t, []string{"a.md", "d.md"}, graph.Orphans())
+	assert.ElementsMatch(t, []string{c, "d.md"}, graph.DeadEnds())
+}
+
+func TestNote_ExportGraph(t *testing.T) {
+	// Arrange
+	vaultDir := t.TempDir()
+	writeVaultFiles(t, vaultDir, map[string]string{
+		"a.md":      "---\nstatus: active\n---\n[[b]] [[b|again]] ![[b]] ![[pic.png]] [[missing]] #idea",
+		"b.md":      "#idea",
+		"pic.png":   "png",
+		"notes.txt": "not linked",
+	})
+	noteManager := obsidian.Note{}
+
+	t.Run("Notes and links only by default", func(t *testing.T) {
+		// Act
+		graph, err := noteManager.ExportGraph(vaultDir, obsidian.GraphExportOptions{})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []obsidian.GraphNode{
+			{ID: "a.md", Type: obsidian.NoteNode, Label: "a", Attributes: map[string]interface{}{"status": "active"}},
+			{ID: "b.md", Type: obsidian.NoteNode, Label: "b"},
+		}, graph.Nodes)
+		assert.Equal(t, []obsidian.GraphEdge{
+			{Source: "a.md", Target: "b.md", Type: obsidian.LinkEdge, Weight: 2},
+			{Source: "a.md", Target: "b.md", Type: obsidian.EmbedEdge, Weight: 1},
+		}, graph.Edges)
+	})
+
+	t.Run("Tags and attachments as typed nodes", func(t *testing.T) {
+		// Act
+		graph, err := noteManager.ExportGraph(vaultDir, obsidian.GraphExportOptions{Tags: true, Attachments: true})
+
+		// Assert
+		assert.NoError(t, err)
+		var ids []string
+		for _, node := range graph.Nodes {
+			ids = append(ids, node.Type+":"+node.ID)
+		}
+		assert.Equal(t, []string{"note:a.md", "note:b.md", "attachment:pic.png", "tag:#idea"}, ids)
+		assert.Contains(t, graph.Edges, obsidian.GraphEdge{Source: "a.md", Target: "pic.png", Type: obsidian.EmbedEdge, Weight: 1})
+		assert.Contains(t, graph.Edges, obsidian.GraphEdge{Source: "b.md", Target: "#idea", Type: obsidian.TagEdge, Weight: 1})
+	})
+}
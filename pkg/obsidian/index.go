@@ -0,0 +1,412 @@
+package obsidian
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/config"
+)
+
+// IndexVersion is bumped whenever the on-disk index format changes. Index files
+// written with a different version are discarded and rebuilt.
+const IndexVersion = 3
+
+var IndexFile = config.IndexFile
+
+// IndexEntry is the cached metadata of a single note.
+type IndexEntry struct {
+	Path        string                 `json:"path"`
+	ModTime     int64                  `json:"mtime"`
+	Size        int64                  `json:"size"`
+	Headings    []string               `json:"headings,omitempty"`
+	Links       []Link                 `json:"links,omitempty"`
+	Blocks      []string               `json:"blocks,omitempty"`
+	Tags        []string               `json:"tags,omitempty"`
+	Frontmatter map[string]interface{} `json:"frontmatter,omitempty"`
+	Unreadable  bool                   `json:"unreadable,omitempty"`
+}
+
+// VaultIndex caches note metadata for a vault so commands do not have to re-read
+// every note. It is persisted in the CLI config directory and refreshed
+// incrementally: only notes whose mtime or size changed are parsed again.
+type VaultIndex struct {
+	Version   int                    `json:"version"`
+	VaultPath string                 `json:"vault_path"`
+	UpdatedAt time.Time              `json:"updated_at"`
+	Entries   map[string]*IndexEntry `json:"entries"`
+
+	order  []string
+	dirty  bool
+	lookup *noteLookup
+}
+
+// IndexStatus describes how the persisted index compares to the vault on disk.
+type IndexStatus struct {
+	VaultPath string    `json:"vault_path"`
+	IndexFile string    `json:"index_file"`
+	Exists    bool      `json:"exists"`
+	Notes     int       `json:"notes"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Added     int       `json:"added"`
+	Modified  int       `json:"modified"`
+	Removed   int       `json:"removed"`
+}
+
+var (
+	indexCacheMutex sync.Mutex
+	indexCache      = make(map[string]*VaultIndex)
+)
+
+// LoadIndex returns the index of the vault, refreshed against the notes on disk.
+func LoadIndex(vaultPath string) (*VaultIndex, error) {
+	idx, err := openIndex(vaultPath)
+	if err != nil {
+		return nil, err
+	}
+	if err := idx.Refresh(); err != nil {
+		return nil, err
+	}
+	if idx.dirty {
+		// The index is only a cache, failing to persist it must not fail the command
+		_ = idx.Save()
+	}
+	return idx, nil
+}
+
+// RebuildIndex discards any cached data and indexes every note of the vault again.
+func RebuildIndex(vaultPath string) (*VaultIndex, error) {
+	absVaultPath, err := absoluteVaultPath(vaultPath)
+	if err != nil {
+		return nil, err
+	}
+
+	idx := newVaultIndex(absVaultPath)
+	if err := idx.Refresh(); err != nil {
+		return nil, err
+	}
+	if err := idx.Save(); err != nil {
+		return nil, err
+	}
+
+	indexCacheMutex.Lock()
+	indexCache[absVaultPath] = idx
+	indexCacheMutex.Unlock()
+	return idx, nil
+}
+
+// GetIndexStatus reports the state of the persisted index without updating it.
+func GetIndexStatus(vaultPath string) (IndexStatus, error) {
+	idx, err := openIndex(vaultPath)
+	if err != nil {
+		return IndexStatus{}, err
+	}
+
+	status := IndexStatus{
+		VaultPath: idx.VaultPath,
+		Notes:     len(idx.Entries),
+		UpdatedAt: idx.UpdatedAt,
+	}
+	if indexFile, err := IndexFile(idx.VaultPath); err == nil {
+		status.IndexFile = indexFile
+		if _, err := os.Stat(indexFile); err == nil {
+			status.Exists = true
+		}
+	}
+
+	seen := make(map[string]bool, len(idx.Entries))
+	err = walkVaultNotes(idx.VaultPath, func(relPath string, info fs.FileInfo) {
+		seen[relPath] = true
+		entry, ok := idx.Entries[relPath]
+		if !ok {
+			status.Added++
+		} else if entry.isStale(info) {
+			status.Modified++
+		}
+	})
+	if err != nil {
+		return IndexStatus{}, err
+	}
+	for relPath := range idx.Entries {
+		if !seen[relPath] {
+			status.Removed++
+		}
+	}
+
+	return status, nil
+}
+
+func newVaultIndex(vaultPath string) *VaultIndex {
+	return &VaultIndex{
+		Version:   IndexVersion,
+		VaultPath: vaultPath,
+		Entries:   make(map[string]*IndexEntry),
+		dirty:     true,
+	}
+}
+
+func absoluteVaultPath(vaultPath string) (string, error) {
+	if strings.TrimSpace(vaultPath) == "" {
+		return "", ErrVaultAccess
+	}
+	absVaultPath, err := filepath.Abs(vaultPath)
+	if err != nil {
+		return "", ErrVaultAccess
+	}
+	info, err := os.Stat(absVaultPath)
+	if err != nil || !info.IsDir() {
+		return "", ErrVaultAccess
+	}
+	return absVaultPath, nil
+}
+
+// openIndex returns the in-memory index for the vault, loading it from disk on first use.
+func openIndex(vaultPath string) (*VaultIndex, error) {
+	absVaultPath, err := absoluteVaultPath(vaultPath)
+	if err != nil {
+		return nil, err
+	}
+
+	indexCacheMutex.Lock()
+	defer indexCacheMutex.Unlock()
+
+	if idx, ok := indexCache[absVaultPath]; ok {
+		return idx, nil
+	}
+
+	idx := readIndexFile(absVaultPath)
+	if idx == nil {
+		idx = newVaultIndex(absVaultPath)
+	}
+	indexCache[absVaultPath] = idx
+	return idx, nil
+}
+
+func readIndexFile(vaultPath string) *VaultIndex {
+	indexFile, err := IndexFile(vaultPath)
+	if err != nil {
+		return nil
+	}
+	content, err := os.ReadFile(indexFile)
+	if err != nil {
+		return nil
+	}
+
+	// Numbers in frontmatter are decoded as json.Number so integers come back as
+	// integers rather than floats, as they were parsed from YAML.
+	idx := &VaultIndex{}
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+	if err := decoder.Decode(idx); err != nil {
+		return nil
+	}
+	if idx.Version != IndexVersion || idx.VaultPath != vaultPath || idx.Entries == nil {
+		return nil
+	}
+	for _, entry := range idx.Entries {
+		if entry != nil && entry.Frontmatter != nil {
+			entry.Frontmatter = normalizeFrontmatter(entry.Frontmatter)
+		}
+	}
+	return idx
+}
+
+// Save writes the index to the CLI config directory.
+func (idx *VaultIndex) Save() error {
+	indexFile, err := IndexFile(idx.VaultPath)
+	if err != nil {
+		return err
+	}
+	if err := writeIndexFile(indexFile, idx); err != nil {
+		return err
+	}
+	idx.dirty = false
+	return nil
+}
+
+// writeIndexFile serialises an index as JSON. It writes to a temporary file first
+// so concurrent runs never read a partially written index.
+func writeIndexFile(indexFile string, index interface{}) error {
+	return writeJSONFile(indexFile, index, IndexWriteError)
+}
+
+// writeJSONFile replaces a file of the CLI config directory with v as JSON,
+// reporting failures with message.
+func writeJSONFile(file string, v interface{}, message string) error {
+	content, err := json.Marshal(v)
+	if err != nil {
+		return errors.New(message)
+	}
+
+	dir := filepath.Dir(file)
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return newWriteError(message, dir, err)
+	}
+
+	return writeFileAtomic(file, content, 0600, message)
+}
+
+// Refresh walks the vault and re-parses notes that were added or changed since
+// they were last indexed. Notes that no longer exist are dropped.
+func (idx *VaultIndex) Refresh() error {
+	seen := make(map[string]bool, len(idx.Entries))
+	order := make([]string, 0, len(idx.Entries))
+
+	err := walkVaultNotes(idx.VaultPath, func(relPath string, info fs.FileInfo) {
+		seen[relPath] = true
+		order = append(order, relPath)
+		if entry, ok := idx.Entries[relPath]; ok && !entry.isStale(info) {
+			return
+		}
+		idx.Entries[relPath] = parseIndexEntry(idx.VaultPath, relPath, info)
+		idx.dirty = true
+	})
+	if err != nil {
+		return err
+	}
+
+	for relPath := range idx.Entries {
+		if !seen[relPath] {
+			delete(idx.Entries, relPath)
+			idx.dirty = true
+		}
+	}
+
+	idx.order = order
+	idx.lookup = nil
+	if idx.dirty {
+		idx.UpdatedAt = time.Now()
+	}
+	return nil
+}
+
+// refreshEntry re-indexes a single note after the CLI has written to it.
+func (idx *VaultIndex) refreshEntry(relPath string) {
+	info, err := os.Stat(filepath.Join(idx.VaultPath, relPath))
+	if err != nil {
+		return
+	}
+	idx.Entries[relPath] = parseIndexEntry(idx.VaultPath, relPath, info)
+	idx.lookup = nil
+	idx.UpdatedAt = time.Now()
+	idx.dirty = true
+}
+
+// Notes returns the vault-relative paths of all indexed notes in directory walk order.
+func (idx *VaultIndex) Notes() []string {
+	return append([]string{}, idx.order...)
+}
+
+// LinkCandidates returns the notes that may contain a link to the given note,
+// compared case-insensitively by file name and by vault-relative path.
+// Notes that could not be read or are too large to index are always included.
+func (idx *VaultIndex) LinkCandidates(notePath string) []string {
+	normalized := normalizePathSeparators(notePath)
+	targets := map[string]bool{
+		strings.ToLower(RemoveMdSuffix(normalized)):            true,
+		strings.ToLower(RemoveMdSuffix(path.Base(normalized))): true,
+	}
+
+	var candidates []string
+	for _, relPath := range idx.order {
+		entry := idx.Entries[relPath]
+		if entry.unparsed() {
+			candidates = append(candidates, relPath)
+			continue
+		}
+		for _, link := range entry.Links {
+			if targets[normalizeLinkTarget(link.Target)] {
+				candidates = append(candidates, relPath)
+				break
+			}
+		}
+	}
+	return candidates
+}
+
+// normalizeLinkTarget reduces a link target to a lower case vault path without extension.
+func normalizeLinkTarget(target string) string {
+	target = normalizePathSeparators(strings.TrimSpace(target))
+	target = strings.TrimPrefix(target, "./")
+	return strings.ToLower(RemoveMdSuffix(target))
+}
+
+// unparsed reports whether the content of a note is missing from the index, because
+// the note could not be read or is larger than maxFileSizeBytes.
+func (entry *IndexEntry) unparsed() bool {
+	return entry.Unreadable || entry.Size > maxFileSizeBytes
+}
+
+func (entry *IndexEntry) isStale(info fs.FileInfo) bool {
+	return entry.Unreadable || entry.ModTime != info.ModTime().UnixNano() || entry.Size != info.Size()
+}
+
+func parseIndexEntry(vaultPath, relPath string, info fs.FileInfo) *IndexEntry {
+	entry := &IndexEntry{
+		Path:    relPath,
+		ModTime: info.ModTime().UnixNano(),
+		Size:    info.Size(),
+	}
+	if info.Size() > maxFileSizeBytes {
+		return entry
+	}
+
+	content, err := os.ReadFile(filepath.Join(vaultPath, relPath))
+	if err != nil {
+		entry.Unreadable = true
+		return entry
+	}
+
+	metadata := ParseNoteMetadata(string(content))
+	entry.Headings = metadata.Headings
+	entry.Links = metadata.Links
+	entry.Blocks = metadata.Blocks
+	entry.Tags = metadata.Tags
+	entry.Frontmatter = metadata.Frontmatter
+	return entry
+}
+
+// walkVaultNotes calls fn for every markdown note in the vault, skipping hidden files and folders.
+func walkVaultNotes(vaultPath string, fn func(relPath string, info fs.FileInfo)) error {
+	return filepath.WalkDir(vaultPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if path == vaultPath {
+				return ErrVaultAccess
+			}
+			if d != nil && d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if path == vaultPath {
+			return nil
+		}
+		if strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() || filepath.Ext(d.Name()) != ".md" {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		relPath, err := filepath.Rel(vaultPath, path)
+		if err != nil {
+			return nil
+		}
+		fn(relPath, info)
+		return nil
+	})
+}
@@ -0,0 +1,163 @@
+package obsidian_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/frontmatter"
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeVaultFiles(t *testing.T, vaultDir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		fullPath := filepath.Join(vaultDir, name)
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(fullPath, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestLoadIndex(t *testing.T) {
+	t.Run("Indexes note metadata and skips hidden folders", func(t *testing.T) {
+		// Arrange
+		vaultDir := t.TempDir()
+		writeVaultFiles(t, vaultDir, map[string]string{
+			"note.md":             "---\ntags: [project]\nstatus: active\n---\n# Title\nSee [[other]] and [doc](folder/doc.md) #idea",
+			"folder/doc.md":       "Plain content",
+			"attachment.png":      "binary",
+			".obsidian/config.md": "hidden",
+		})
+
+		// Act
+		idx, err := obsidian.LoadIndex(vaultDir)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []string{"note.md", filepath.Join("folder", "doc.md")}, idx.Notes())
+
+		entry := idx.Entries["note.md"]
+		assert.Equal(t, []string{"Title"}, entry.Headings)
+		assert.Equal(t, []string{"other", "folder/doc.md"}, linkTargets(entry.Links))
+		assert.Equal(t, []string{"project", "idea"}, entry.Tags)
+		assert.Equal(t, "active", entry.Frontmatter["status"])
+	})
+
+	t.Run("Persists the index and picks up changes by mtime", func(t *testing.T) {
+		// Arrange
+		vaultDir := t.TempDir()
+		writeVaultFiles(t, vaultDir, map[string]string{
+			"keep.md":   "unchanged",
+			"edit.md":   "before",
+			"remove.md": "going away",
+		})
+		_, err := obsidian.LoadIndex(vaultDir)
+		assert.NoError(t, err)
+
+		// Act
+		writeVaultFiles(t, vaultDir, map[string]string{
+			"edit.md": "after [[keep]]",
+			"new.md":  "fresh",
+		})
+		future := time.Now().Add(time.Minute)
+		assert.NoError(t, os.Chtimes(filepath.Join(vaultDir, "edit.md"), future, future))
+		assert.NoError(t, os.Remove(filepath.Join(vaultDir, "remove.md")))
+		status, err := obsidian.GetIndexStatus(vaultDir)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.True(t, status.Exists)
+		assert.Equal(t, 3, status.Notes)
+		assert.Equal(t, 1, status.Added)
+		assert.Equal(t, 1, status.Modified)
+		assert.Equal(t, 1, status.Removed)
+
+		idx, err := obsidian.LoadIndex(vaultDir)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"keep"}, linkTargets(idx.Entries["edit.md"].Links))
+
+		status, err = obsidian.GetIndexStatus(vaultDir)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, status.Notes)
+		assert.Equal(t, 0, status.Added+status.Modified+status.Removed)
+	})
+
+	t.Run("Frontmatter numbers survive the persisted index", func(t *testing.T) {
+		// Arrange
+		vaultDir := t.TempDir()
+		writeVaultFiles(t, vaultDir, map[string]string{"note.md": "---\nid: 20240101\nrating: 4.5\n---\n"})
+		_, err := obsidian.LoadIndex(vaultDir)
+		assert.NoError(t, err)
+		obsidian.ForgetIndexes()
+
+		// Act
+		idx, err := obsidian.LoadIndex(vaultDir)
+
+		// Assert
+		assert.NoError(t, err)
+		fm := idx.Entries["note.md"].Frontmatter
+		assert.Equal(t, 20240101, fm["id"])
+		assert.True(t, frontmatter.MatchesFilter(fm, map[string]string{"id": "20240101", "rating": "4.5"}))
+	})
+
+	t.Run("Rebuild indexes every note again", func(t *testing.T) {
+		// Arrange
+		vaultDir := t.TempDir()
+		writeVaultFiles(t, vaultDir, map[string]string{"a.md": "a", "b/c.md": "c"})
+
+		// Act
+		idx, err := obsidian.RebuildIndex(vaultDir)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, idx.Entries, 2)
+	})
+
+	t.Run("Error on missing vault directory", func(t *testing.T) {
+		// Act
+		_, err := obsidian.LoadIndex(filepath.Join(t.TempDir(), "missing"))
+
+		// Assert
+		assert.Equal(t, obsidian.VaultAccessError, err.Error())
+	})
+}
+
+func TestVaultIndexLinkCandidates(t *testing.T) {
+	// Arrange
+	vaultDir := t.TempDir()
+	writeVaultFiles(t, vaultDir, map[string]string{
+		"target.md":       "target",
+		"folder/deep.md":  "deep",
+		"wiki.md":         "[[Target|alias]]",
+		"markdown.md":     "[x](./folder/deep.md)",
+		"unrelated.md":    "[[something else]]",
+		"path-link.md":    "[[folder/deep#heading]]",
+		"code-mention.md": "no links here",
+	})
+	idx, err := obsidian.LoadIndex(vaultDir)
+	assert.NoError(t, err)
+
+	// Act & Assert
+	assert.Equal(t, []string{"wiki.md"}, idx.LinkCandidates("target"))
+	assert.ElementsMatch(t, []string{"markdown.md", "path-link.md"}, idx.LinkCandidates("folder/deep.md"))
+
+	t.Run("Notes too large to index are always candidates", func(t *testing.T) {
+		// Arrange
+		writeVaultFiles(t, vaultDir, map[string]string{"large.md": "[[target]]\n" + strings.Repeat("x", 10*1024*1024)})
+		idx, err := obsidian.LoadIndex(vaultDir)
+		assert.NoError(t, err)
+
+		// Act
+		candidates := idx.LinkCandidates("target")
+
+		// Assert
+		assert.ElementsMatch(t, []string{"wiki.md", "large.md"}, candidates)
+	})
+}
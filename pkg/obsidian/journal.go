@@ -0,0 +1,322 @@
+package obsidian
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/config"
+)
+
+// MaxJournalOperations is the number of operations kept in the journal of a
+// vault; older operations can no longer be undone.
+const MaxJournalOperations = 100
+
+var JournalDir = config.JournalDir
+
+// JournalOperation is one command that changed the vault.
+type JournalOperation struct {
+	ID      int             `json:"id"`
+	Time    time.Time       `json:"time"`
+	Command string          `json:"command"`
+	Changes []JournalChange `json:"changes"`
+}
+
+// JournalChange is the change an operation made to one file or folder, with the
+// same statuses as a FileChange. Paths are vault-relative with forward slashes.
+// The hashes are SHA-256 hashes of the content: BeforeHash is empty for created
+// files and AfterHash for deleted ones. The content before the change is kept in
+// the journal so it can be restored.
+type JournalChange struct {
+	Path       string      `json:"path"`
+	NewPath    string      `json:"new_path,omitempty"`
+	Status     string      `json:"status"`
+	BeforeHash string      `json:"before_hash,omitempty"`
+	AfterHash  string      `json:"after_hash,omitempty"`
+	Mode       fs.FileMode `json:"mode,omitempty"`
+}
+
+// Journal records the changes the CLI makes to a vault so they can be undone. It
+// is stored in the CLI config directory: the operations in a JSON file and the
+// previous content of changed files by hash.
+type Journal struct {
+	VaultPath string
+	Command   string
+}
+
+// NewJournal returns the journal of the vault at vaultPath. Operations recorded
+// through it are described by command.
+func NewJournal(vaultPath string, command string) *Journal {
+	if absVaultPath, err := filepath.Abs(vaultPath); err == nil {
+		vaultPath = absVaultPath
+	}
+	return &Journal{VaultPath: vaultPath, Command: command}
+}
+
+func (j *Journal) files() (operationsFile string, objectsDir string, err error) {
+	dir, err := JournalDir(j.VaultPath)
+	if err != nil {
+		return "", "", err
+	}
+	return filepath.Join(dir, "operations.json"), filepath.Join(dir, "objects"), nil
+}
+
+// Operations returns the recorded operations, oldest first.
+func (j *Journal) Operations() ([]JournalOperation, error) {
+	operationsFile, _, err := j.files()
+	if err != nil {
+		return nil, err
+	}
+	content, err := os.ReadFile(operationsFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read the operation journal %s", operationsFile)
+	}
+
+	var operations []JournalOperation
+	if err := json.Unmarshal(content, &operations); err != nil {
+		return nil, fmt.Errorf("Failed to parse the operation journal %s", operationsFile)
+	}
+	return operations, nil
+}
+
+// record adds the changes of a transaction that has just been applied.
+func (j *Journal) record(tx *Transaction) error {
+	_, objectsDir, err := j.files()
+	if err != nil {
+		return err
+	}
+
+	var changes []JournalChange
+	for _, move := range tx.moves {
+		if !move.removed {
+			changes = append(changes, JournalChange{Path: j.relative(move.from), NewPath: j.relative(move.to), Status: FileMoved})
+			continue
+		}
+		info, err := os.Stat(move.to)
+		if err != nil {
+			return err
+		}
+		content, err := os.ReadFile(move.to)
+		if err != nil {
+			return err
+		}
+		hash, err := storeObject(objectsDir, content)
+		if err != nil {
+			return err
+		}
+		changes = append(changes, JournalChange{Path: j.relative(move.from), Status: FileDeleted, BeforeHash: hash, Mode: info.Mode().Perm()})
+	}
+	for _, write := range tx.writes {
+		change := JournalChange{Path: j.relative(write.path), Status: FileCreated, AfterHash: write.hash, Mode: write.mode}
+		if write.existed {
+			hash, err := storeObject(objectsDir, write.original)
+			if err != nil {
+				return err
+			}
+			change.Status, change.BeforeHash = FileModified, hash
+		}
+		changes = append(changes, change)
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+
+	operations, err := j.Operations()
+	if err != nil {
+		return err
+	}
+	id := 1
+	if len(operations) > 0 {
+		id = operations[len(operations)-1].ID + 1
+	}
+	operations = append(operations, JournalOperation{ID: id, Time: time.Now(), Command: j.Command, Changes: changes})
+	if len(operations) > MaxJournalOperations {
+		operations = operations[len(operations)-MaxJournalOperations:]
+	}
+	return j.save(operations)
+}
+
+// save replaces the recorded operations and drops the stored content that none
+// of them refers to anymore.
+func (j *Journal) save(operations []JournalOperation) error {
+	operationsFile, objectsDir, err := j.files()
+	if err != nil {
+		return err
+	}
+	if operations == nil {
+		operations = []JournalOperation{}
+	}
+	if err := writeJSONFile(operationsFile, operations, JournalWriteError); err != nil {
+		return err
+	}
+
+	referenced := make(map[string]bool)
+	for _, operation := range operations {
+		for _, change := range operation.Changes {
+			referenced[change.BeforeHash] = true
+		}
+	}
+	objects, _ := os.ReadDir(objectsDir)
+	for _, object := range objects {
+		if !referenced[object.Name()] {
+			os.Remove(filepath.Join(objectsDir, object.Name()))
+		}
+	}
+	return nil
+}
+
+// Undo reverts the last count operations, most recent first, and removes them
+// from the journal. The changes are applied together in one transaction. An
+// operation is only reverted if the files it changed still have the content it
+// left, unless force is set.
+func (j *Journal) Undo(count int, force bool) ([]JournalOperation, error) {
+	operations, err := j.Operations()
+	if err != nil {
+		return nil, err
+	}
+	if len(operations) == 0 {
+		return nil, errors.New(NothingToUndoError)
+	}
+	if count < 1 || count > len(operations) {
+		return nil, fmt.Errorf("Cannot undo %d operations, the journal has %d", count, len(operations))
+	}
+
+	var undone []JournalOperation
+	tx := NewTransaction()
+	for i := len(operations) - 1; i >= len(operations)-count; i-- {
+		if err := j.revert(tx, operations[i], force); err != nil {
+			return nil, tx.rollback(err)
+		}
+		undone = append(undone, operations[i])
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	if err := j.save(operations[:len(operations)-count]); err != nil {
+		return undone, err
+	}
+	return undone, nil
+}
+
+// revert stages the changes that undo an operation, checking that the files are
+// still as the operation left them.
+func (j *Journal) revert(tx *Transaction, operation JournalOperation, force bool) error {
+	_, objectsDir, err := j.files()
+	if err != nil {
+		return err
+	}
+	changedSince := func(path string) error {
+		return fmt.Errorf("%s was changed after %q, use --force to undo it anyway", path, operation.Command)
+	}
+
+	for i := len(operation.Changes) - 1; i >= 0; i-- {
+		change := operation.Changes[i]
+		path := j.absolute(change.Path)
+
+		switch change.Status {
+		case FileMoved:
+			newPath := j.absolute(change.NewPath)
+			if !fileExists(newPath) || fileExists(path) {
+				return fmt.Errorf("Cannot move %s back to %s", change.NewPath, change.Path)
+			}
+			if err := tx.MkdirAll(filepath.Dir(path)); err != nil {
+				return err
+			}
+			if err := tx.Rename(newPath, path); err != nil {
+				return newWriteError(VaultWriteError, path, err)
+			}
+
+		case FileDeleted:
+			if fileExists(path) && !force {
+				return changedSince(change.Path)
+			}
+			if err := restoreObject(tx, objectsDir, path, change); err != nil {
+				return err
+			}
+
+		case FileCreated, FileModified:
+			current, err := tx.Read(path)
+			if err != nil && !force {
+				return changedSince(change.Path)
+			}
+			if err == nil && contentHash(current) != change.AfterHash && !force {
+				return changedSince(change.Path)
+			}
+			if change.Status == FileModified {
+				if err := restoreObject(tx, objectsDir, path, change); err != nil {
+					return err
+				}
+			} else if err == nil {
+				if err := tx.Remove(path); err != nil {
+					return newWriteError(VaultWriteError, path, err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// restoreObject stages the content a file had before a change.
+func restoreObject(tx *Transaction, objectsDir string, path string, change JournalChange) error {
+	content, err := os.ReadFile(filepath.Join(objectsDir, change.BeforeHash))
+	if err != nil {
+		return fmt.Errorf("The previous content of %s is missing from the journal", change.Path)
+	}
+	if err := tx.MkdirAll(filepath.Dir(path)); err != nil {
+		return err
+	}
+	mode := change.Mode
+	if mode == 0 {
+		mode = 0644
+	}
+	return tx.Write(path, content, mode)
+}
+
+// storeObject keeps content in the journal under its hash and returns the hash.
+func storeObject(objectsDir string, content []byte) (string, error) {
+	hash := contentHash(content)
+	path := filepath.Join(objectsDir, hash)
+	if fileExists(path) {
+		return hash, nil
+	}
+	if err := os.MkdirAll(objectsDir, os.ModePerm); err != nil {
+		return "", newWriteError(JournalWriteError, objectsDir, err)
+	}
+	if err := writeFileAtomic(path, content, 0600, JournalWriteError); err != nil {
+		return "", err
+	}
+	return hash, nil
+}
+
+func contentHash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// relative returns the vault-relative path of a file, or the absolute path of a
+// file outside the vault.
+func (j *Journal) relative(path string) string {
+	relPath, err := filepath.Rel(j.VaultPath, path)
+	if err != nil || relPath == ".." || strings.HasPrefix(relPath, ".."+string(filepath.Separator)) {
+		return path
+	}
+	return normalizePathSeparators(relPath)
+}
+
+func (j *Journal) absolute(path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(j.VaultPath, filepath.FromSlash(path))
+}
@@ -0,0 +1,144 @@
+package obsidian_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJournal(t *testing.T) {
+	moveNote := func(t *testing.T, vaultDir string, journal *obsidian.Journal) {
+		t.Helper()
+		noteManager := obsidian.Note{Journal: journal}
+		err := noteManager.Transact(func() error {
+			if err := noteManager.Move(filepath.Join(vaultDir, "old"), filepath.Join(vaultDir, "new")); err != nil {
+				return err
+			}
+			return noteManager.UpdateLinks(vaultDir, "old", "new")
+		})
+		assert.NoError(t, err)
+	}
+
+	t.Run("Records the changes of a transaction as one operation", func(t *testing.T) {
+		// Arrange
+		vaultDir := t.TempDir()
+		writeVaultFiles(t, vaultDir, map[string]string{"old.md": "content", "a.md": "See [[old]]"})
+		journal := obsidian.NewJournal(vaultDir, "move old new")
+
+		// Act
+		moveNote(t, vaultDir, journal)
+		operations, err := journal.Operations()
+
+		// Assert
+		assert.NoError(t, err)
+		if assert.Len(t, operations, 1) {
+			assert.Equal(t, "move old new", operations[0].Command)
+			assert.Equal(t, []obsidian.JournalChange{
+				{Path: "old.md", NewPath: "new.md", Status: obsidian.FileMoved},
+				{Path: "a.md", Status: obsidian.FileModified, BeforeHash: operations[0].Changes[1].BeforeHash, AfterHash: operations[0].Changes[1].AfterHash, Mode: 0644},
+			}, operations[0].Changes)
+			assert.NotEmpty(t, operations[0].Changes[1].BeforeHash)
+		}
+	})
+
+	t.Run("Undo reverts the last operations", func(t *testing.T) {
+		// Arrange
+		vaultDir := t.TempDir()
+		writeVaultFiles(t, vaultDir, map[string]string{"old.md": "content", "a.md": "See [[old]]", "b.md": "b"})
+		journal := obsidian.NewJournal(vaultDir, "")
+		moveNote(t, vaultDir, journal)
+		noteManager := obsidian.Note{Journal: journal}
+		assert.NoError(t, noteManager.Delete(filepath.Join(vaultDir, "b")))
+		assert.NoError(t, noteManager.Create(filepath.Join(vaultDir, "c.md"), "c", false))
+
+		// Act
+		undone, err := journal.Undo(3, false)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, undone, 3)
+		assert.Equal(t, "content", readVaultFile(t, filepath.Join(vaultDir, "old.md")))
+		assert.Equal(t, "See [[old]]", readVaultFile(t, filepath.Join(vaultDir, "a.md")))
+		assert.Equal(t, "b", readVaultFile(t, filepath.Join(vaultDir, "b.md")))
+		assert.NoFileExists(t, filepath.Join(vaultDir, "new.md"))
+		assert.NoFileExists(t, filepath.Join(vaultDir, "c.md"))
+		operations, _ := journal.Operations()
+		assert.Empty(t, operations)
+	})
+
+	t.Run("Undo reverts a create and a delete of the same file together", func(t *testing.T) {
+		// Arrange
+		vaultDir := t.TempDir()
+		journal := obsidian.NewJournal(vaultDir, "")
+		noteManager := obsidian.Note{Journal: journal}
+		assert.NoError(t, noteManager.Create(filepath.Join(vaultDir, "c.md"), "c", false))
+		assert.NoError(t, noteManager.Delete(filepath.Join(vaultDir, "c")))
+
+		// Act
+		undone, err := journal.Undo(2, false)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, undone, 2)
+		assert.NoFileExists(t, filepath.Join(vaultDir, "c.md"))
+		entries, _ := os.ReadDir(vaultDir)
+		for _, entry := range entries {
+			assert.NotContains(t, entry.Name(), ".tmp-", "no staged file is left behind")
+		}
+	})
+
+	t.Run("Undo refuses files changed since unless forced", func(t *testing.T) {
+		// Arrange
+		vaultDir := t.TempDir()
+		writeVaultFiles(t, vaultDir, map[string]string{"old.md": "content", "a.md": "See [[old]]"})
+		journal := obsidian.NewJournal(vaultDir, "move old new")
+		moveNote(t, vaultDir, journal)
+		assert.NoError(t, os.WriteFile(filepath.Join(vaultDir, "a.md"), []byte("See [[new]] again"), 0644))
+
+		// Act
+		_, err := journal.Undo(1, false)
+		unchanged := readVaultFile(t, filepath.Join(vaultDir, "a.md"))
+		_, statErr := os.Stat(filepath.Join(vaultDir, "new.md"))
+		_, forceErr := journal.Undo(1, true)
+
+		// Assert
+		assert.ErrorContains(t, err, "a.md was changed after")
+		assert.Equal(t, "See [[new]] again", unchanged)
+		assert.NoError(t, statErr, "nothing is reverted when one file was changed")
+		assert.NoError(t, forceErr)
+		assert.Equal(t, "See [[old]]", readVaultFile(t, filepath.Join(vaultDir, "a.md")))
+		assert.FileExists(t, filepath.Join(vaultDir, "old.md"))
+	})
+
+	t.Run("Nothing to undo", func(t *testing.T) {
+		// Arrange
+		journal := obsidian.NewJournal(t.TempDir(), "")
+
+		// Act
+		_, err := journal.Undo(1, false)
+
+		// Assert
+		assert.EqualError(t, err, obsidian.NothingToUndoError)
+	})
+
+	t.Run("Keeps only the most recent operations", func(t *testing.T) {
+		// Arrange
+		vaultDir := t.TempDir()
+		journal := obsidian.NewJournal(vaultDir, "")
+		noteManager := obsidian.Note{Journal: journal}
+
+		// Act
+		for i := 0; i <= obsidian.MaxJournalOperations; i++ {
+			assert.NoError(t, noteManager.Create(filepath.Join(vaultDir, "note.md"), "x", i > 0))
+		}
+		operations, err := journal.Operations()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, operations, obsidian.MaxJournalOperations)
+		assert.Equal(t, 2, operations[0].ID)
+	})
+}
@@ -0,0 +1,366 @@
+package obsidian
+
+import (
+	"io/fs"
+	"net/url"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// LinkKind is the syntax a link is written in.
+type LinkKind string
+
+const (
+	WikiLinkKind     LinkKind = "wikilink"
+	MarkdownLinkKind LinkKind = "markdown"
+)
+
+// Link is an outgoing link found in a note. Target is empty for links to a
+// heading or block in the same note.
+type Link struct {
+	Kind    LinkKind `json:"kind"`
+	Embed   bool     `json:"embed,omitempty"`
+	Target  string   `json:"target"`
+	Heading string   `json:"heading,omitempty"`
+	Block   string   `json:"block,omitempty"`
+	Alias   string   `json:"alias,omitempty"`
+	Line    int      `json:"line"`
+}
+
+// ResolvedLink is a link together with the vault-relative path it points to.
+// Path is empty when no note or attachment matches the target.
+type ResolvedLink struct {
+	Link
+	Path   string `json:"path,omitempty"`
+	Exists bool   `json:"exists"`
+}
+
+var (
+	wikiLinkRegex     = regexp.MustCompile(`(!?)\[\[([^\[\]\n]+)\]\]`)
+	markdownLinkRegex = regexp.MustCompile(`(!?)\[([^\[\]\n]*)\]\(([^()\n]*)\)`)
+	urlSchemeRegex    = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9+.-]*:`)
+)
+
+// ExtractLinks returns the wikilinks, embeds and markdown links of a note in
+// the order they appear. Links inside code fences and inline code are ignored,
+// as are markdown links to external URLs.
+func ExtractLinks(content string) []Link {
+	var links []Link
+	inFence := false
+	for i, line := range strings.Split(content, "\n") {
+		line = strings.TrimRight(line, "\r")
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		links = append(links, extractLineLinks(maskInlineCode(line), i+1)...)
+	}
+	return links
+}
+
+func extractLineLinks(line string, lineNumber int) []Link {
+	found := findLineLinks(line, lineNumber)
+	links := make([]Link, len(found))
+	for i, f := range found {
+		links[i] = f.link
+	}
+	return links
+}
+
+// lineLink is a link found in a line, with the byte offsets of its text.
+type lineLink struct {
+	start, end int
+	link       Link
+}
+
+// findLineLinks returns the links in a line in the order they appear.
+func findLineLinks(line string, lineNumber int) []lineLink {
+	var links []lineLink
+	for _, match := range wikiLinkRegex.FindAllStringSubmatchIndex(line, -1) {
+		link := parseWikiLink(line[match[4]:match[5]])
+		link.Embed = match[3] > match[2]
+		link.Line = lineNumber
+		links = append(links, lineLink{match[0], match[1], link})
+	}
+	for _, match := range markdownLinkRegex.FindAllStringSubmatchIndex(line, -1) {
+		link, ok := parseMarkdownLink(line[match[4]:match[5]], line[match[6]:match[7]])
+		if !ok {
+			continue
+		}
+		link.Embed = match[3] > match[2]
+		link.Line = lineNumber
+		links = append(links, lineLink{match[0], match[1], link})
+	}
+
+	sort.SliceStable(links, func(i, j int) bool { return links[i].start < links[j].start })
+	return links
+}
+
+// replaceLinks calls replace for every link of a note that ExtractLinks finds,
+// with the link and its text, and puts the text it returns in place of the link.
+// Links inside code are left alone.
+func replaceLinks(content []byte, replace func(link Link, text string) string) []byte {
+	lines := strings.SplitAfter(string(content), "\n")
+	inFence := false
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+
+		var b strings.Builder
+		last := 0
+		for _, found := range findLineLinks(maskInlineCode(line), i+1) {
+			if found.start < last {
+				continue
+			}
+			b.WriteString(line[last:found.start])
+			b.WriteString(replace(found.link, line[found.start:found.end]))
+			last = found.end
+		}
+		b.WriteString(line[last:])
+		lines[i] = b.String()
+	}
+	return []byte(strings.Join(lines, ""))
+}
+
+// parseWikiLink splits the inside of [[...]] into target, heading or block, and alias.
+func parseWikiLink(inner string) Link {
+	link := Link{Kind: WikiLinkKind}
+	if i := strings.Index(inner, "|"); i >= 0 {
+		link.Alias = strings.TrimSpace(inner[i+1:])
+		// Pipes are escaped inside tables
+		inner = strings.TrimSuffix(inner[:i], `\`)
+	}
+	link.Target, link.Heading, link.Block = splitLinkAnchor(inner)
+	return link
+}
+
+// parseMarkdownLink parses the destination of [text](destination). It reports
+// false for external URLs and empty destinations.
+func parseMarkdownLink(text, destination string) (Link, bool) {
+	destination = strings.TrimSpace(destination)
+	if strings.HasPrefix(destination, "<") {
+		if end := strings.Index(destination, ">"); end > 0 {
+			destination = destination[1:end]
+		}
+	} else if i := strings.IndexAny(destination, " \t"); i >= 0 {
+		// Drop an optional link title: [text](note.md "title")
+		destination = destination[:i]
+	}
+	if destination == "" || urlSchemeRegex.MatchString(destination) {
+		return Link{}, false
+	}
+	if unescaped, err := url.PathUnescape(destination); err == nil {
+		destination = unescaped
+	}
+
+	link := Link{Kind: MarkdownLinkKind, Alias: strings.TrimSpace(text)}
+	link.Target, link.Heading, link.Block = splitLinkAnchor(destination)
+	return link, true
+}
+
+// splitLinkAnchor splits "note#heading" and "note#^block" into their parts.
+func splitLinkAnchor(target string) (string, string, string) {
+	var anchor string
+	if i := strings.IndexAny(target, "#^"); i >= 0 {
+		anchor = target[i:]
+		target = target[:i]
+	}
+	target = strings.TrimSpace(target)
+	anchor = strings.TrimPrefix(anchor, "#")
+	if strings.HasPrefix(anchor, "^") {
+		return target, "", strings.TrimSpace(anchor[1:])
+	}
+	return target, strings.TrimSpace(anchor), ""
+}
+
+// maskInlineCode blanks out inline code spans so links inside them are not extracted.
+func maskInlineCode(line string) string {
+	if !strings.Contains(line, "`") {
+		return line
+	}
+
+	masked := []byte(line)
+	for i := 0; i < len(line); {
+		if line[i] != '`' {
+			i++
+			continue
+		}
+		run := backtickRun(line, i)
+		end := closingBacktickRun(line, i+run, run)
+		if end < 0 {
+			i += run
+			continue
+		}
+		for j := i; j < end+run; j++ {
+			masked[j] = ' '
+		}
+		i = end + run
+	}
+	return string(masked)
+}
+
+func backtickRun(line string, start int) int {
+	n := 0
+	for start+n < len(line) && line[start+n] == '`' {
+		n++
+	}
+	return n
+}
+
+// closingBacktickRun finds the next run of exactly n backticks at or after start.
+func closingBacktickRun(line string, start, n int) int {
+	for i := start; i < len(line); {
+		if line[i] != '`' {
+			i++
+			continue
+		}
+		run := backtickRun(line, i)
+		if run == n {
+			return i
+		}
+		i += run
+	}
+	return -1
+}
+
+// ResolveLink returns the vault-relative path a link in the note at source points
+// to. Like Obsidian, targets are matched case-insensitively, first as a path and
+// then by file name; markdown links are also tried relative to the source note.
+// Targets that are not notes are looked up as attachments in the vault.
+func (idx *VaultIndex) ResolveLink(source string, link Link) (string, bool) {
+	if link.Target == "" {
+		return source, true
+	}
+
+	target := strings.TrimPrefix(normalizePathSeparators(link.Target), "./")
+	var candidates []string
+	if link.Kind == MarkdownLinkKind && !strings.HasPrefix(target, "/") {
+		candidates = append(candidates, path.Join(path.Dir(normalizePathSeparators(source)), target))
+	}
+	candidates = append(candidates, path.Clean(strings.TrimPrefix(target, "/")))
+
+	for _, candidate := range candidates {
+		if relPath, err := idx.ResolveNote(candidate, source); err == nil {
+			return relPath, true
+		}
+	}
+	for _, candidate := range candidates {
+		if relPath, ok := idx.resolveAttachment(candidate); ok {
+			return relPath, true
+		}
+	}
+	return "", false
+}
+
+// resolveAttachment matches a non-note file by vault path, then by path suffix or file name.
+func (idx *VaultIndex) resolveAttachment(target string) (string, bool) {
+	if path.Ext(target) == "" || path.Ext(target) == ".md" {
+		return "", false
+	}
+	key := foldName(target)
+	lookup := idx.noteLookup()
+	if lookup.attachments == nil {
+		lookup.attachments = walkVaultAttachments(idx.VaultPath)
+	}
+	for _, relPath := range lookup.attachments[path.Base(key)] {
+		filePath := foldName(normalizePathSeparators(relPath))
+		if filePath == key || strings.HasSuffix(filePath, "/"+key) || !strings.Contains(key, "/") {
+			return relPath, true
+		}
+	}
+	return "", false
+}
+
+// noteLookup holds the lookup tables used to resolve links and note names. It is
+// built on first use and discarded whenever the index changes.
+type noteLookup struct {
+	exact       map[string]string
+	byPath      map[string]string
+	byName      map[string][]string
+	aliases     map[string][]string
+	attachments map[string][]string
+}
+
+func (idx *VaultIndex) noteLookup() *noteLookup {
+	if idx.lookup != nil {
+		return idx.lookup
+	}
+	lookup := &noteLookup{
+		exact:   make(map[string]string, len(idx.order)),
+		byPath:  make(map[string]string, len(idx.order)),
+		byName:  make(map[string][]string, len(idx.order)),
+		aliases: make(map[string][]string),
+	}
+	for _, relPath := range idx.order {
+		notePath := RemoveMdSuffix(normalizePathSeparators(relPath))
+		key := foldName(notePath)
+		lookup.exact[notePath] = relPath
+		lookup.byPath[key] = relPath
+		lookup.byName[path.Base(key)] = append(lookup.byName[path.Base(key)], relPath)
+
+		seen := make(map[string]bool)
+		for _, alias := range frontmatterAliases(idx.Entries[relPath].Frontmatter) {
+			alias = foldName(strings.TrimSpace(alias))
+			if alias != "" && !seen[alias] {
+				seen[alias] = true
+				lookup.aliases[alias] = append(lookup.aliases[alias], relPath)
+			}
+		}
+	}
+	idx.lookup = lookup
+	return lookup
+}
+
+// walkVaultAttachments lists the files in the vault that are not notes, keyed by lower case file name.
+func walkVaultAttachments(vaultPath string) map[string][]string {
+	attachments := make(map[string][]string)
+	_ = filepath.WalkDir(vaultPath, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || p == vaultPath {
+			return nil
+		}
+		if strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() || filepath.Ext(d.Name()) == ".md" {
+			return nil
+		}
+		relPath, err := filepath.Rel(vaultPath, p)
+		if err != nil {
+			return nil
+		}
+		name := foldName(d.Name())
+		attachments[name] = append(attachments[name], relPath)
+		return nil
+	})
+	return attachments
+}
+
+// ResolveLinks resolves every outgoing link of the note at relPath.
+func (idx *VaultIndex) ResolveLinks(relPath string) []ResolvedLink {
+	entry, ok := idx.Entries[relPath]
+	if !ok {
+		return nil
+	}
+	resolved := make([]ResolvedLink, 0, len(entry.Links))
+	for _, link := range entry.Links {
+		target, exists := idx.ResolveLink(relPath, link)
+		resolved = append(resolved, ResolvedLink{Link: link, Path: target, Exists: exists})
+	}
+	return resolved
+}
@@ -0,0 +1,128 @@
+package obsidian_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/stretchr/testify/assert"
+)
+
+func linkTargets(links []obsidian.Link) []string {
+	targets := make([]string, 0, len(links))
+	for _, link := range links {
+		targets = append(targets, link.Target)
+	}
+	return targets
+}
+
+func TestExtractLinks(t *testing.T) {
+	t.Run("Parses wikilinks, embeds, anchors and aliases", func(t *testing.T) {
+		// Act
+		links := obsidian.ExtractLinks("[[Note]] ![[image.png]]\n[[Note#Heading|shown]] [[Note#^abc123]] [[#Local]]")
+
+		// Assert
+		assert.Equal(t, []obsidian.Link{
+			{Kind: obsidian.WikiLinkKind, Target: "Note", Line: 1},
+			{Kind: obsidian.WikiLinkKind, Embed: true, Target: "image.png", Line: 1},
+			{Kind: obsidian.WikiLinkKind, Target: "Note", Heading: "Heading", Alias: "shown", Line: 2},
+			{Kind: obsidian.WikiLinkKind, Target: "Note", Block: "abc123", Line: 2},
+			{Kind: obsidian.WikiLinkKind, Heading: "Local", Line: 2},
+		}, links)
+	})
+
+	t.Run("Parses markdown links and skips external URLs", func(t *testing.T) {
+		// Act
+		links := obsidian.ExtractLinks(`[text](folder/My%20Note.md#Part) ![img](<pics/a b.png>) [site](https://example.com) [mail](mailto:a@b.c) [t](note.md "title")`)
+
+		// Assert
+		assert.Equal(t, []obsidian.Link{
+			{Kind: obsidian.MarkdownLinkKind, Target: "folder/My Note.md", Heading: "Part", Alias: "text", Line: 1},
+			{Kind: obsidian.MarkdownLinkKind, Embed: true, Target: "pics/a b.png", Alias: "img", Line: 1},
+			{Kind: obsidian.MarkdownLinkKind, Target: "note.md", Alias: "t", Line: 1},
+		}, links)
+	})
+
+	t.Run("Ignores links in code fences and inline code", func(t *testing.T) {
+		// Act
+		links := obsidian.ExtractLinks("```\n[[fenced]]\n```\n`[[inline]]` and ``[[double `tick`]]`` but [[real]]\n~~~\n[x](tilde.md)\n~~~")
+
+		// Assert
+		assert.Equal(t, []string{"real"}, linkTargets(links))
+		assert.Equal(t, 4, links[0].Line)
+	})
+
+	t.Run("Unescapes pipes in tables", func(t *testing.T) {
+		// Act
+		links := obsidian.ExtractLinks(`| [[Note\|alias]] |`)
+
+		// Assert
+		assert.Equal(t, []obsidian.Link{{Kind: obsidian.WikiLinkKind, Target: "Note", Alias: "alias", Line: 1}}, links)
+	})
+}
+
+func TestNote_OutgoingLinks(t *testing.T) {
+	t.Run("Resolves links to notes and attachments", func(t *testing.T) {
+		// Arrange
+		vaultDir := t.TempDir()
+		writeVaultFiles(t, vaultDir, map[string]string{
+			"folder/source.md":       "[[Target]] [[sub/deep]] [sibling](sibling.md) [root](/Target.md) ![[pic.png]] [[missing]] [[#Heading]]",
+			"Target.md":              "target",
+			"other/sub/deep.md":      "deep",
+			"folder/sibling.md":      "sibling",
+			"attachments/pic.png":    "png",
+			"attachments/ignored.md": "note",
+		})
+		noteManager := obsidian.Note{}
+
+		// Act
+		links, err := noteManager.OutgoingLinks(vaultDir, "source")
+
+		// Assert
+		assert.NoError(t, err)
+		var paths []string
+		var exists []bool
+		for _, link := range links {
+			paths = append(paths, link.Path)
+			exists = append(exists, link.Exists)
+		}
+		assert.Equal(t, []string{
+			"Target.md",
+			filepath.Join("other", "sub", "deep.md"),
+			filepath.Join("folder", "sibling.md"),
+			"Target.md",
+			filepath.Join("attachments", "pic.png"),
+			"",
+			filepath.Join("folder", "source.md"),
+		}, paths)
+		assert.Equal(t, []bool{true, true, true, true, true, false, true}, exists)
+	})
+
+	t.Run("Matches targets case-insensitively", func(t *testing.T) {
+		// Arrange
+		vaultDir := t.TempDir()
+		writeVaultFiles(t, vaultDir, map[string]string{
+			"source.md":      "[[target note]]",
+			"Target Note.md": "target",
+		})
+		noteManager := obsidian.Note{}
+
+		// Act
+		links, err := noteManager.OutgoingLinks(vaultDir, "source")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "Target Note.md", links[0].Path)
+	})
+
+	t.Run("Error on missing note", func(t *testing.T) {
+		// Arrange
+		noteManager := obsidian.Note{}
+
+		// Act
+		_, err := noteManager.OutgoingLinks(t.TempDir(), "missing")
+
+		// Assert
+		assert.ErrorIs(t, err, obsidian.ErrNoteNotFound)
+	})
+}
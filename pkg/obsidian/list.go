@@ -1,7 +1,6 @@
 package obsidian
 
 import (
-	"errors"
 	"os"
 	"path/filepath"
 	"sort"
@@ -23,7 +22,7 @@ func GlobEntries(vaultPath, pattern string) ([]string, error) {
 
 	matches, err := doublestar.FilepathGlob(fullPattern)
 	if err != nil {
-		return nil, errors.New(VaultReadError)
+		return nil, ErrVaultRead
 	}
 
 	results := make([]string, 0, len(matches))
@@ -62,15 +61,15 @@ func ListEntries(vaultPath, relativePath string) ([]string, error) {
 
 	info, err := os.Stat(targetPath)
 	if err != nil {
-		return nil, errors.New(VaultAccessError)
+		return nil, ErrVaultAccess
 	}
 	if !info.IsDir() {
-		return nil, errors.New(VaultAccessError)
+		return nil, ErrVaultAccess
 	}
 
 	entries, err := os.ReadDir(targetPath)
 	if err != nil {
-		return nil, errors.New(VaultReadError)
+		return nil, ErrVaultRead
 	}
 
 	dirs := make([]string, 0, len(entries))
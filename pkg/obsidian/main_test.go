@@ -0,0 +1,12 @@
+package obsidian_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/Yakitrak/obsidian-cli/mocks"
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(mocks.RunWithTempConfig(m))
+}
@@ -0,0 +1,282 @@
+package obsidian
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/frontmatter"
+)
+
+var bareURLRegex = regexp.MustCompile(`[a-zA-Z][a-zA-Z0-9+.-]*://\S+`)
+
+// Mention is a plain-text occurrence of a note's name or one of its aliases in
+// another note. Line and Column are 1-based; Column counts characters.
+type Mention struct {
+	Source  string `json:"source"`
+	Line    int    `json:"line"`
+	Column  int    `json:"column"`
+	Text    string `json:"text"`
+	Context string `json:"context"`
+
+	// offset is the byte offset of Text within its line
+	offset int
+}
+
+// UnlinkedMentions returns the places where other notes mention the note at relPath
+// by its name or one of its frontmatter aliases without linking to it, in vault
+// walk order. Matches are case-insensitive and whole-word. Frontmatter, code, links
+// and URLs are not searched.
+func (idx *VaultIndex) UnlinkedMentions(relPath string) []Mention {
+	terms := mentionTerms(relPath, idx.Entries[relPath])
+	if len(terms) == 0 {
+		return nil
+	}
+	pattern := mentionPattern(terms)
+
+	var mentions []Mention
+	for _, source := range idx.order {
+		if source == relPath || idx.Entries[source].Unreadable || idx.Entries[source].Size > maxFileSizeBytes {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(idx.VaultPath, source))
+		if err != nil || !containsAnyTerm(bytes.ToLower(content), terms) {
+			continue
+		}
+		mentions = append(mentions, findMentions(source, string(content), pattern)...)
+	}
+	return mentions
+}
+
+// linkMentions turns the given unlinked mentions of the note at relPath into
+// wikilinks: [[Note]] when the mention is written exactly like the link target,
+// [[Note|text]] otherwise so the text reads the same. Mentions are looked up again
+// by source, line and column, and those that no longer match are skipped. It
+// returns the mentions that were linked.
+func (idx *VaultIndex) linkMentions(relPath string, selected []Mention, files noteFiles) ([]Mention, error) {
+	terms := mentionTerms(relPath, idx.Entries[relPath])
+	if len(terms) == 0 {
+		return nil, nil
+	}
+	pattern := mentionPattern(terms)
+	target := idx.linkTarget(relPath)
+
+	wanted := make(map[string]map[[2]int]bool)
+	var sources []string
+	for _, mention := range selected {
+		if wanted[mention.Source] == nil {
+			wanted[mention.Source] = make(map[[2]int]bool)
+			sources = append(sources, mention.Source)
+		}
+		wanted[mention.Source][[2]int{mention.Line, mention.Column}] = true
+	}
+
+	var linked []Mention
+	for _, source := range sources {
+		if _, ok := idx.Entries[source]; !ok || source == relPath {
+			continue
+		}
+		content, err := files.readNote(source)
+		if err != nil {
+			return linked, err
+		}
+
+		var matches []Mention
+		for _, mention := range findMentions(source, string(content), pattern) {
+			if wanted[source][[2]int{mention.Line, mention.Column}] {
+				matches = append(matches, mention)
+			}
+		}
+		if len(matches) == 0 {
+			continue
+		}
+
+		updated := linkMentionsInContent(string(content), matches, target)
+		if err := files.writeNote(source, []byte(updated)); err != nil {
+			return linked, err
+		}
+		linked = append(linked, matches...)
+	}
+	return linked, nil
+}
+
+// linkTarget returns the shortest wikilink target that resolves to the note: its
+// name, or its path when other notes share the name.
+func (idx *VaultIndex) linkTarget(relPath string) string {
+	notePath := RemoveMdSuffix(normalizePathSeparators(relPath))
+	name := path.Base(notePath)
+	if len(idx.noteLookup().byName[foldName(name)]) > 1 {
+		return notePath
+	}
+	return name
+}
+
+// mentionTerms returns the note name and its frontmatter aliases, longest first so
+// "Java Script" is preferred over "Java" where both match.
+func mentionTerms(relPath string, entry *IndexEntry) []string {
+	var terms []string
+	seen := make(map[string]bool)
+	add := func(term string) {
+		term = strings.TrimSpace(term)
+		if term == "" || seen[strings.ToLower(term)] {
+			return
+		}
+		seen[strings.ToLower(term)] = true
+		terms = append(terms, term)
+	}
+
+	add(RemoveMdSuffix(path.Base(normalizePathSeparators(relPath))))
+	if entry != nil {
+		for _, alias := range frontmatterAliases(entry.Frontmatter) {
+			add(alias)
+		}
+	}
+	sort.SliceStable(terms, func(i, j int) bool { return len(terms[i]) > len(terms[j]) })
+	return terms
+}
+
+// frontmatterAliases reads the aliases or alias property, which may be a list or a
+// comma separated string.
+func frontmatterAliases(fm map[string]interface{}) []string {
+	var aliases []string
+	for _, key := range []string{"aliases", "alias"} {
+		switch v := fm[key].(type) {
+		case string:
+			aliases = append(aliases, strings.Split(v, ",")...)
+		case []interface{}:
+			for _, item := range v {
+				if item != nil {
+					aliases = append(aliases, fmt.Sprintf("%v", item))
+				}
+			}
+		}
+	}
+	return aliases
+}
+
+// mentionPattern matches any of the terms, captured in the first group, where it
+// is not followed by a word character. A term that runs into a longer word gives
+// way to a shorter term at the same position, so "Foo" is found in "Foo Barista"
+// even when "Foo Bar" is a term too.
+func mentionPattern(terms []string) *regexp.Regexp {
+	quoted := make([]string, len(terms))
+	for i, term := range terms {
+		quoted[i] = regexp.QuoteMeta(term)
+	}
+	return regexp.MustCompile(`(?i)(` + strings.Join(quoted, "|") + `)(?:[^\pL\pN_]|$)`)
+}
+
+func containsAnyTerm(contentLower []byte, terms []string) bool {
+	for _, term := range terms {
+		if bytes.Contains(contentLower, []byte(strings.ToLower(term))) {
+			return true
+		}
+	}
+	return false
+}
+
+// findMentions returns the whole-word matches of pattern in the searchable parts of content.
+func findMentions(source, content string, pattern *regexp.Regexp) []Mention {
+	var mentions []Mention
+	inFrontmatter := frontmatter.HasFrontmatter(content)
+	inFence := false
+	for i, line := range strings.Split(content, "\n") {
+		line = strings.TrimRight(line, "\r")
+		trimmed := strings.TrimSpace(line)
+		if inFrontmatter {
+			if i > 0 && trimmed == frontmatter.Delimiter {
+				inFrontmatter = false
+			}
+			continue
+		}
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+
+		searchable := maskMentionExclusions(line)
+		for pos := 0; pos < len(searchable); {
+			match := pattern.FindStringSubmatchIndex(searchable[pos:])
+			if match == nil {
+				break
+			}
+			start, end := pos+match[2], pos+match[3]
+			if !isWordBoundary(searchable, start, end) {
+				// The term continues a word, try again from the next character
+				_, size := utf8.DecodeRuneInString(searchable[start:])
+				pos = start + size
+				continue
+			}
+			mentions = append(mentions, Mention{
+				Source:  source,
+				Line:    i + 1,
+				Column:  utf8.RuneCountInString(line[:start]) + 1,
+				Text:    line[start:end],
+				Context: trimmed,
+				offset:  start,
+			})
+			pos = end
+		}
+	}
+	return mentions
+}
+
+// maskMentionExclusions blanks out inline code, links and URLs, keeping byte offsets.
+func maskMentionExclusions(line string) string {
+	masked := []byte(maskInlineCode(line))
+	for _, re := range []*regexp.Regexp{wikiLinkRegex, markdownLinkRegex, bareURLRegex} {
+		for _, match := range re.FindAllIndex(masked, -1) {
+			for j := match[0]; j < match[1]; j++ {
+				masked[j] = ' '
+			}
+		}
+	}
+	return string(masked)
+}
+
+// isWordBoundary reports whether line[start:end] is not part of a longer word or a tag.
+func isWordBoundary(line string, start, end int) bool {
+	if before, _ := utf8.DecodeLastRuneInString(line[:start]); start > 0 && (isWordRune(before) || before == '#') {
+		return false
+	}
+	if after, _ := utf8.DecodeRuneInString(line[end:]); end < len(line) && isWordRune(after) {
+		return false
+	}
+	return true
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsNumber(r) || r == '_'
+}
+
+// linkMentionsInContent replaces the mentions, which must all come from content, with wikilinks to target.
+func linkMentionsInContent(content string, mentions []Mention, target string) string {
+	lines := strings.Split(content, "\n")
+	sort.SliceStable(mentions, func(i, j int) bool {
+		if mentions[i].Line != mentions[j].Line {
+			return mentions[i].Line < mentions[j].Line
+		}
+		return mentions[i].offset < mentions[j].offset
+	})
+	// Replace from the end of each line so earlier offsets stay valid
+	for i := len(mentions) - 1; i >= 0; i-- {
+		mention := mentions[i]
+		line := lines[mention.Line-1]
+		link := "[[" + target + "]]"
+		if mention.Text != target {
+			link = "[[" + target + "|" + mention.Text + "]]"
+		}
+		lines[mention.Line-1] = line[:mention.offset] + link + line[mention.offset+len(mention.Text):]
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,153 @@
+package obsidian_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNote_FindUnlinkedMentions(t *testing.T) {
+	t.Run("Finds the note name and aliases outside frontmatter, code and links", func(t *testing.T) {
+		// Arrange
+		vaultDir := t.TempDir()
+		writeVaultFiles(t, vaultDir, map[string]string{
+			"JavaScript.md": "---\naliases: [JS, ECMAScript]\n---\nThe JavaScript note",
+			"other.md": "---\ntitle: JavaScript\n---\nI like javascript and JS.\n" +
+				"`JavaScript` [[JavaScript]] [js](JavaScript.md) #JavaScript https://javascript.com\n" +
+				"JavaScripts and JSON\n```\nJavaScript\n```\nECMAScript is JS",
+		})
+		noteManager := obsidian.Note{}
+
+		// Act
+		mentions, err := noteManager.FindUnlinkedMentions(vaultDir, "JavaScript")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []obsidian.Mention{
+			{Source: "other.md", Line: 4, Column: 8, Text: "javascript", Context: "I like javascript and JS."},
+			{Source: "other.md", Line: 4, Column: 23, Text: "JS", Context: "I like javascript and JS."},
+			{Source: "other.md", Line: 10, Column: 1, Text: "ECMAScript", Context: "ECMAScript is JS"},
+			{Source: "other.md", Line: 10, Column: 15, Text: "JS", Context: "ECMAScript is JS"},
+		}, stripMentionOffsets(mentions))
+	})
+
+	t.Run("Finds a shorter name where a longer alias runs into a word", func(t *testing.T) {
+		// Arrange
+		vaultDir := t.TempDir()
+		writeVaultFiles(t, vaultDir, map[string]string{
+			"Foo.md":   "---\naliases: [Foo Bar]\n---\n",
+			"other.md": "I like Foo Barista and Foo here.\nFoo Barx\nFoo Bar, Foo",
+		})
+		noteManager := obsidian.Note{}
+
+		// Act
+		mentions, err := noteManager.FindUnlinkedMentions(vaultDir, "Foo")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []obsidian.Mention{
+			{Source: "other.md", Line: 1, Column: 8, Text: "Foo", Context: "I like Foo Barista and Foo here."},
+			{Source: "other.md", Line: 1, Column: 24, Text: "Foo", Context: "I like Foo Barista and Foo here."},
+			{Source: "other.md", Line: 2, Column: 1, Text: "Foo", Context: "Foo Barx"},
+			{Source: "other.md", Line: 3, Column: 1, Text: "Foo Bar", Context: "Foo Bar, Foo"},
+			{Source: "other.md", Line: 3, Column: 10, Text: "Foo", Context: "Foo Bar, Foo"},
+		}, stripMentionOffsets(mentions))
+	})
+
+	t.Run("Note not found", func(t *testing.T) {
+		// Arrange
+		vaultDir := t.TempDir()
+		noteManager := obsidian.Note{}
+
+		// Act
+		_, err := noteManager.FindUnlinkedMentions(vaultDir, "missing")
+
+		// Assert
+		assert.ErrorIs(t, err, obsidian.ErrNoteNotFound)
+	})
+}
+
+func TestNote_LinkMentions(t *testing.T) {
+	t.Run("Links the selected mentions and keeps the text", func(t *testing.T) {
+		// Arrange
+		vaultDir := t.TempDir()
+		writeVaultFiles(t, vaultDir, map[string]string{
+			"JavaScript.md": "---\naliases: JS\n---\n",
+			"a.md":          "JavaScript, javascript and JS\r\nJS again",
+			"b.md":          "JS",
+		})
+		noteManager := obsidian.Note{}
+		mentions, err := noteManager.FindUnlinkedMentions(vaultDir, "JavaScript")
+		assert.NoError(t, err)
+
+		// Act
+		linked, err := noteManager.LinkMentions(vaultDir, "JavaScript", mentions[:3])
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, linked, 3)
+		content, _ := os.ReadFile(filepath.Join(vaultDir, "a.md"))
+		assert.Equal(t, "[[JavaScript]], [[JavaScript|javascript]] and [[JavaScript|JS]]\r\nJS again", string(content))
+		content, _ = os.ReadFile(filepath.Join(vaultDir, "b.md"))
+		assert.Equal(t, "JS", string(content))
+		remaining, err := noteManager.FindUnlinkedMentions(vaultDir, "JavaScript")
+		assert.NoError(t, err)
+		assert.Len(t, remaining, 2)
+	})
+
+	t.Run("Uses the path when the note name is not unique", func(t *testing.T) {
+		// Arrange
+		vaultDir := t.TempDir()
+		writeVaultFiles(t, vaultDir, map[string]string{
+			"a/Topic.md": "",
+			"b/Topic.md": "",
+			"c.md":       "Topic",
+		})
+		noteManager := obsidian.Note{}
+		mentions, err := noteManager.FindUnlinkedMentions(vaultDir, "a/Topic")
+		assert.NoError(t, err)
+
+		// Act
+		_, err = noteManager.LinkMentions(vaultDir, "a/Topic", mentions)
+
+		// Assert
+		assert.NoError(t, err)
+		content, _ := os.ReadFile(filepath.Join(vaultDir, "c.md"))
+		assert.Equal(t, "[[a/Topic|Topic]]", string(content))
+	})
+
+	t.Run("Skips mentions that no longer match", func(t *testing.T) {
+		// Arrange
+		vaultDir := t.TempDir()
+		writeVaultFiles(t, vaultDir, map[string]string{
+			"Note.md":  "",
+			"other.md": "A Note here",
+		})
+		noteManager := obsidian.Note{}
+		mentions, err := noteManager.FindUnlinkedMentions(vaultDir, "Note")
+		assert.NoError(t, err)
+		writeVaultFiles(t, vaultDir, map[string]string{"other.md": "Changed: Note"})
+
+		// Act
+		linked, err := noteManager.LinkMentions(vaultDir, "Note", mentions)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Empty(t, linked)
+		content, _ := os.ReadFile(filepath.Join(vaultDir, "other.md"))
+		assert.Equal(t, "Changed: Note", string(content))
+	})
+}
+
+// stripMentionOffsets copies mentions through their exported fields so they compare
+// equal to literals.
+func stripMentionOffsets(mentions []obsidian.Mention) []obsidian.Mention {
+	stripped := make([]obsidian.Mention, len(mentions))
+	for i, m := range mentions {
+		stripped[i] = obsidian.Mention{Source: m.Source, Line: m.Line, Column: m.Column, Text: m.Text, Context: m.Context}
+	}
+	return stripped
+}
@@ -0,0 +1,148 @@
+package obsidian
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/frontmatter"
+)
+
+var (
+	headingRegex   = regexp.MustCompile(`^#{1,6}[ \t]+(.*?)(?:[ \t]+#+)?[ \t]*$`)
+	inlineTagRegex = regexp.MustCompile(`(?:^|\s)#([\p{L}\p{N}_/-]+)`)
+	blockIDRegex   = regexp.MustCompile(`(?:^|\s)\^([A-Za-z0-9-]+)[ \t]*$`)
+)
+
+// NoteMetadata is the information extracted from a note's content for the vault index.
+type NoteMetadata struct {
+	Headings    []string
+	Links       []Link
+	Blocks      []string
+	Tags        []string
+	Frontmatter map[string]interface{}
+}
+
+// ParseNoteMetadata extracts headings, outgoing links, block IDs, tags and frontmatter from note content.
+func ParseNoteMetadata(content string) NoteMetadata {
+	var metadata NoteMetadata
+	body := content
+
+	if frontmatter.HasFrontmatter(content) {
+		if fm, rest, err := frontmatter.Parse(content); err == nil {
+			body = rest
+			if len(fm) > 0 {
+				metadata.Frontmatter = normalizeFrontmatter(fm)
+			}
+		}
+	}
+
+	metadata.Links = ExtractLinks(content)
+
+	seenTags := make(map[string]bool)
+	addTag := func(tag string) {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "#")
+		if tag == "" || seenTags[tag] {
+			return
+		}
+		seenTags[tag] = true
+		metadata.Tags = append(metadata.Tags, tag)
+	}
+
+	for _, tag := range frontmatterTags(metadata.Frontmatter) {
+		addTag(tag)
+	}
+
+	inFence := false
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimRight(line, "\r")
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		if match := blockIDRegex.FindStringSubmatch(line); match != nil {
+			metadata.Blocks = append(metadata.Blocks, match[1])
+		}
+		if match := headingRegex.FindStringSubmatch(line); match != nil {
+			metadata.Headings = append(metadata.Headings, match[1])
+			continue
+		}
+		for _, match := range inlineTagRegex.FindAllStringSubmatch(line, -1) {
+			if isValidTag(match[1]) {
+				addTag(match[1])
+			}
+		}
+	}
+
+	return metadata
+}
+
+// isValidTag reports whether a tag contains at least one non-numeric character, as Obsidian requires.
+func isValidTag(tag string) bool {
+	return strings.IndexFunc(tag, func(r rune) bool { return !unicode.IsDigit(r) }) >= 0
+}
+
+// frontmatterTags reads the tags or tag property, which may be a list or a comma separated string.
+func frontmatterTags(fm map[string]interface{}) []string {
+	var tags []string
+	for _, key := range []string{"tags", "tag"} {
+		switch v := fm[key].(type) {
+		case string:
+			for _, tag := range strings.FieldsFunc(v, func(r rune) bool { return r == ',' || unicode.IsSpace(r) }) {
+				tags = append(tags, tag)
+			}
+		case []interface{}:
+			for _, item := range v {
+				if item != nil {
+					tags = append(tags, fmt.Sprintf("%v", item))
+				}
+			}
+		}
+	}
+	return tags
+}
+
+// normalizeFrontmatter converts YAML maps keyed by interface{} into string keyed maps
+// so the frontmatter can be serialised as JSON.
+func normalizeFrontmatter(fm map[string]interface{}) map[string]interface{} {
+	normalized := make(map[string]interface{}, len(fm))
+	for key, value := range fm {
+		normalized[key] = normalizeFrontmatterValue(value)
+	}
+	return normalized
+}
+
+func normalizeFrontmatterValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		normalized := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			normalized[fmt.Sprintf("%v", key)] = normalizeFrontmatterValue(item)
+		}
+		return normalized
+	case map[string]interface{}:
+		return normalizeFrontmatter(v)
+	case []interface{}:
+		normalized := make([]interface{}, len(v))
+		for i, item := range v {
+			normalized[i] = normalizeFrontmatterValue(item)
+		}
+		return normalized
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return int(i)
+		}
+		if f, err := v.Float64(); err == nil {
+			return f
+		}
+		return v.String()
+	default:
+		return v
+	}
+}
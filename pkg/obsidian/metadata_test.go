@@ -0,0 +1,47 @@
+package obsidian_test
+
+import (
+	"testing"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseNoteMetadata(t *testing.T) {
+	t.Run("Headings and tags inside code fences are ignored", func(t *testing.T) {
+		// Arrange
+		content := "# Real heading\n```\n# not a heading #notatag\n```\n## Second ##\ntext #tag #123"
+
+		// Act
+		metadata := obsidian.ParseNoteMetadata(content)
+
+		// Assert
+		assert.Equal(t, []string{"Real heading", "Second"}, metadata.Headings)
+		assert.Equal(t, []string{"tag"}, metadata.Tags)
+	})
+
+	t.Run("Link targets drop aliases and headings", func(t *testing.T) {
+		// Act
+		metadata := obsidian.ParseNoteMetadata("[[a|alias]] ![[b#heading]] [[c^block]] [text](d.md)")
+
+		// Assert
+		assert.Equal(t, []string{"a", "b", "c", "d.md"}, linkTargets(metadata.Links))
+	})
+
+	t.Run("Block IDs at the end of lines", func(t *testing.T) {
+		// Act
+		metadata := obsidian.ParseNoteMetadata("A paragraph ^para-1\n- item ^item2\nnot^ablock\n```\ncode ^fenced\n```")
+
+		// Assert
+		assert.Equal(t, []string{"para-1", "item2"}, metadata.Blocks)
+	})
+
+	t.Run("Nested frontmatter is normalised and string tags are split", func(t *testing.T) {
+		// Act
+		metadata := obsidian.ParseNoteMetadata("---\ntags: one, two\nnested:\n  key: value\n---\nbody")
+
+		// Assert
+		assert.Equal(t, []string{"one", "two"}, metadata.Tags)
+		assert.Equal(t, map[string]interface{}{"key": "value"}, metadata.Frontmatter["nested"])
+	})
+}
@@ -2,275 +2,415 @@ package obsidian
 
 import (
 	"bytes"
-	"errors"
 	"fmt"
 	"io"
-	"io/fs"
 	"os"
+	"path"
 	"path/filepath"
 	"sort"
 	"strings"
 )
 
 type Note struct {
+	// Journal records the changes so they can be undone. It is optional.
+	Journal *Journal
+
+	tx *Transaction
 }
 
 type NoteMatch struct {
 	FilePath   string
 	LineNumber int
 	MatchLine  string
+	Score      float64
 }
 
 type NoteManager interface {
+	Create(string, string, bool) error
 	Move(string, string) error
+	MoveFolder(string, string) error
+	UpdateFolderLinks(string, string, string) error
 	Delete(string) error
+	Trash(string, string) (string, error)
+	ListTrash(string) ([]TrashedNote, error)
+	RestoreTrash(string, string) (TrashedNote, error)
 	UpdateLinks(string, string, string) error
+	UnlinkNote(string, string) error
+	ResolveNote(string, string) (string, error)
 	GetContents(string, string) (string, error)
 	SetContents(string, string, string) error
 	GetNotesList(string) ([]string, error)
-	SearchNotesWithSnippets(string, string) ([]NoteMatch, error)
+	SearchNotesWithSnippets(string, string, SearchOptions) ([]NoteMatch, error)
 	FindBacklinks(string, string) ([]NoteMatch, error)
+	OutgoingLinks(string, string) ([]ResolvedLink, error)
+	FindBrokenLinks(string) ([]BrokenLink, error)
+	GetLinkGraph(string) (*LinkGraph, error)
+	ExportGraph(string, GraphExportOptions) (*GraphExport, error)
+	FindUnlinkedMentions(string, string) ([]Mention, error)
+	LinkMentions(string, string, []Mention) ([]Mention, error)
+	Transact(func() error) error
 }
 
-func (m *Note) Move(originalPath string, newPath string) error {
-	o := AddMdSuffix(originalPath)
-	n := AddMdSuffix(newPath)
-
-	err := os.Rename(o, n)
-
-	if err != nil {
-		return errors.New(NoteDoesNotExistError)
+// Transact runs fn with every change the note manager makes grouped in one
+// transaction: the changes are applied when fn succeeds and rolled back when fn
+// or applying them fails. Calls inside fn join the running transaction.
+func (m *Note) Transact(fn func() error) error {
+	if m.tx != nil {
+		return fn()
 	}
 
-	message := fmt.Sprintf(`Moved note 
-from %s
-to %s`, o, n)
-
-	fmt.Println(message)
-	return nil
-}
-func (m *Note) Delete(path string) error {
-	note := AddMdSuffix(path)
-	err := os.Remove(note)
-	if err != nil {
-		return errors.New(NoteDoesNotExistError)
+	tx := NewTransaction()
+	tx.journal = m.Journal
+	m.tx = tx
+	defer func() { m.tx = nil }()
+	if err := fn(); err != nil {
+		return tx.rollback(err)
 	}
-	fmt.Println("Deleted note: ", note)
-	return nil
+	return tx.Commit()
 }
 
-func (m *Note) GetContents(vaultPath string, noteName string) (string, error) {
-	note := AddMdSuffix(noteName)
-
-	var notePath string
-	err := filepath.WalkDir(vaultPath, func(path string, d os.DirEntry, err error) error {
-		if err != nil {
-			return err // Continue to the next path if there's an error
+// Create writes a note at the given path, creating its folder as needed. With
+// appendContent, content is added to the end of an existing note instead.
+func (m *Note) Create(path string, content string, appendContent bool) error {
+	return m.Transact(func() error {
+		if err := m.tx.MkdirAll(filepath.Dir(path)); err != nil {
+			return err
 		}
-		if d.IsDir() {
-			return nil // Skip directories
+
+		// New text follows the line endings and byte order mark of the note
+		data := []byte(content)
+		existing, err := m.tx.Read(path)
+		if appendContent {
+			if err != nil {
+				return newWriteError(VaultWriteError, path, err)
+			}
+			data = append(existing, matchLineEndings(existing, data)...)
+		} else if err == nil {
+			data = matchTextFormat(existing, data)
 		}
+		return m.tx.Write(path, data, 0644)
+	})
+}
 
-		// Check for full path match first
-		relPath, err := filepath.Rel(vaultPath, path)
-		if err == nil && relPath == note {
-			notePath = path
-			return filepath.SkipDir
+func (m *Note) Move(originalPath string, newPath string) error {
+	o := AddMdSuffix(originalPath)
+	n := AddMdSuffix(newPath)
+
+	return m.Transact(func() error {
+		if err := m.tx.Rename(o, n); err != nil {
+			return ErrNoteNotFound
 		}
+		return nil
+	})
+}
 
-		// Fall back to basename match for backward compatibility
-		if filepath.Base(path) == note {
-			notePath = path
-			return filepath.SkipDir
+func (m *Note) Delete(path string) error {
+	note := AddMdSuffix(path)
+	return m.Transact(func() error {
+		if err := m.tx.Remove(note); err != nil {
+			return ErrNoteNotFound
 		}
 		return nil
 	})
+}
 
-	if err != nil || notePath == "" {
-		return "", errors.New(NoteDoesNotExistError)
+func (m *Note) GetContents(vaultPath string, noteName string) (string, error) {
+	idx, relPath, err := findIndexedNote(vaultPath, noteName)
+	if err != nil {
+		return "", err
 	}
 
-	file, err := os.Open(notePath)
+	file, err := os.Open(filepath.Join(idx.VaultPath, relPath))
 	if err != nil {
-		return "", errors.New(VaultReadError)
+		return "", ErrVaultRead
 	}
 	defer file.Close()
 
 	content, err := io.ReadAll(file)
 	if err != nil {
-		return "", errors.New(VaultReadError)
+		return "", ErrVaultRead
 	}
 
 	return string(content), nil
 }
 
 func (m *Note) SetContents(vaultPath string, noteName string, content string) error {
-	note := AddMdSuffix(noteName)
-
-	var notePath string
-	err := filepath.WalkDir(vaultPath, func(path string, d os.DirEntry, err error) error {
-		if err != nil {
-			return err
-		}
-		if d.IsDir() {
-			return nil
-		}
+	idx, relPath, err := findIndexedNote(vaultPath, noteName)
+	if err != nil {
+		return err
+	}
 
-		// Check for full path match first
-		relPath, err := filepath.Rel(vaultPath, path)
-		if err == nil && relPath == note {
-			notePath = path
-			return filepath.SkipDir
+	return m.Transact(func() error {
+		path := filepath.Join(idx.VaultPath, relPath)
+		data := []byte(content)
+		if original, err := m.tx.Read(path); err == nil {
+			data = matchTextFormat(original, data)
 		}
-
-		// Fall back to basename match for backward compatibility
-		if filepath.Base(path) == note {
-			notePath = path
-			return filepath.SkipDir
+		if err := m.tx.Write(path, data, 0644); err != nil {
+			return err
 		}
+		m.tx.OnCommit(func() {
+			idx.refreshEntry(relPath)
+			_ = idx.Save()
+		})
 		return nil
 	})
+}
 
-	if err != nil || notePath == "" {
-		return errors.New(NoteDoesNotExistError)
-	}
+// ResolveNote returns the vault-relative path of the note a name refers to, by
+// path, file name or alias.
+func (m *Note) ResolveNote(vaultPath string, noteName string) (string, error) {
+	_, relPath, err := findIndexedNote(vaultPath, noteName)
+	return relPath, err
+}
 
-	err = os.WriteFile(notePath, []byte(content), 0644)
+// findIndexedNote looks up a note by vault-relative path, file name or alias in the vault index.
+func findIndexedNote(vaultPath string, noteName string) (*VaultIndex, string, error) {
+	idx, err := LoadIndex(vaultPath)
 	if err != nil {
-		return errors.New(VaultWriteError)
+		return nil, "", ErrNoteNotFound
 	}
 
-	return nil
+	relPath, err := idx.ResolveNoteName(noteName)
+	if err != nil {
+		return nil, "", err
+	}
+	return idx, relPath, nil
 }
 
 func (m *Note) UpdateLinks(vaultPath string, oldNoteName string, newNoteName string) error {
-	err := filepath.Walk(vaultPath, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return errors.New(VaultAccessError)
+	idx, err := LoadIndex(vaultPath)
+	if err != nil {
+		return err
+	}
+
+	return m.Transact(func() error {
+		if err := updateLinks(idx, diskFiles{idx, m.tx}, oldNoteName, newNoteName); err != nil {
+			return err
 		}
+		m.tx.OnCommit(func() { _ = idx.Save() })
+		return nil
+	})
+}
 
-		if ShouldSkipDirectoryOrFile(info) {
-			return nil
+// UnlinkNote turns the links to a note into plain text in every note of the vault.
+func (m *Note) UnlinkNote(vaultPath string, noteName string) error {
+	idx, err := LoadIndex(vaultPath)
+	if err != nil {
+		return err
+	}
+
+	return m.Transact(func() error {
+		if err := unlinkNote(idx, diskFiles{idx, m.tx}, noteName); err != nil {
+			return err
 		}
+		m.tx.OnCommit(func() { _ = idx.Save() })
+		return nil
+	})
+}
 
-		originalContent, err := os.ReadFile(path)
-		if err != nil {
-			return errors.New(VaultReadError)
+// updateLinks rewrites the links to a moved note in every note that may contain one.
+// Like the index, it leaves links inside code alone.
+func updateLinks(idx *VaultIndex, files noteFiles, oldNoteName string, newNoteName string) error {
+	replacements := GenerateLinkReplacements(oldNoteName, newNoteName)
+	// Links by name must not end up at another note that shares the new name
+	if relPath, err := idx.ResolveNote(newNoteName, ""); err == nil {
+		if target := idx.linkTarget(relPath); target != RemoveMdSuffix(path.Base(target)) {
+			oldBase := RemoveMdSuffix(path.Base(normalizePathSeparators(oldNoteName)))
+			for _, suffix := range []string{"]]", "|", "#"} {
+				replacements["[["+oldBase+suffix] = "[[" + target + suffix
+			}
 		}
+	}
+	return rewriteLinks(idx, files, oldNoteName, func(content []byte) []byte {
+		return replaceLinks(content, func(_ Link, text string) string {
+			return string(ReplaceContent([]byte(text), replacements))
+		})
+	})
+}
 
-		replacements := GenerateLinkReplacements(oldNoteName, newNoteName)
-		updatedContent := ReplaceContent(originalContent, replacements)
+// unlinkNote replaces the links to a note with their text in every note that may
+// contain one.
+func unlinkNote(idx *VaultIndex, files noteFiles, noteName string) error {
+	return rewriteLinks(idx, files, noteName, func(content []byte) []byte {
+		return UnlinkContent(content, noteName)
+	})
+}
 
-		if bytes.Equal(originalContent, updatedContent) {
-			return nil
+// rewriteLinks applies rewrite to every note that may link to noteName and writes
+// the notes it changed.
+func rewriteLinks(idx *VaultIndex, files noteFiles, noteName string, rewrite func([]byte) []byte) error {
+	for _, relPath := range idx.LinkCandidates(noteName) {
+		originalContent, err := files.readNote(relPath)
+		if err != nil {
+			return err
 		}
 
-		err = os.WriteFile(path, updatedContent, info.Mode())
-		if err != nil {
-			return errors.New(VaultWriteError)
+		updatedContent := rewrite(originalContent)
+		if bytes.Equal(originalContent, updatedContent) {
+			continue
 		}
-		return nil
-	})
+		if err := files.writeNote(relPath, updatedContent); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// noteFiles reads and writes notes by vault-relative path. Note stages writes in a
+// transaction, RecordingNote keeps them in memory.
+type noteFiles interface {
+	readNote(relPath string) ([]byte, error)
+	writeNote(relPath string, content []byte) error
+}
+
+// diskFiles reads and writes notes on disk through a transaction and keeps the
+// index up to date once it is committed.
+type diskFiles struct {
+	idx *VaultIndex
+	tx  *Transaction
+}
+
+func (f diskFiles) readNote(relPath string) ([]byte, error) {
+	path := filepath.Join(f.idx.VaultPath, relPath)
+	if _, err := os.Stat(path); err != nil {
+		return nil, ErrVaultAccess
+	}
+	content, err := f.tx.Read(path)
+	if err != nil {
+		return nil, ErrVaultRead
+	}
+	return content, nil
+}
+
+// writeNote replaces the content of a note, keeping its file mode, line endings
+// and byte order mark.
+func (f diskFiles) writeNote(relPath string, content []byte) error {
+	path := filepath.Join(f.idx.VaultPath, relPath)
+	info, err := os.Stat(path)
 	if err != nil {
+		return ErrVaultAccess
+	}
+	if original, err := f.tx.Read(path); err == nil {
+		content = matchTextFormat(original, content)
+	}
+	if err := f.tx.Write(path, content, info.Mode()); err != nil {
 		return err
 	}
+	f.tx.OnCommit(func() { f.idx.refreshEntry(relPath) })
 	return nil
 }
 
-func (m *Note) GetNotesList(vaultPath string) ([]string, error) {
-	var notes []string
-	err := filepath.WalkDir(vaultPath, func(path string, d fs.DirEntry, err error) error {
+// OutgoingLinks returns the links of a note in the order they appear, each resolved
+// to the note or attachment it points to.
+func (m *Note) OutgoingLinks(vaultPath string, noteName string) ([]ResolvedLink, error) {
+	idx, relPath, err := findIndexedNote(vaultPath, noteName)
+	if err != nil {
+		return nil, err
+	}
+	if idx.Entries[relPath].Unreadable {
+		return nil, ErrVaultRead
+	}
+	return idx.ResolveLinks(relPath), nil
+}
+
+// FindBrokenLinks returns every link in the vault whose target note, attachment,
+// heading or block does not exist.
+func (m *Note) FindBrokenLinks(vaultPath string) ([]BrokenLink, error) {
+	idx, err := LoadIndex(vaultPath)
+	if err != nil {
+		return nil, err
+	}
+	return idx.BrokenLinks(), nil
+}
+
+// GetLinkGraph returns the graph of links between the notes of the vault.
+func (m *Note) GetLinkGraph(vaultPath string) (*LinkGraph, error) {
+	idx, err := LoadIndex(vaultPath)
+	if err != nil {
+		return nil, err
+	}
+	return idx.LinkGraph(), nil
+}
+
+// ExportGraph returns the vault graph with typed nodes and edges for export.
+func (m *Note) ExportGraph(vaultPath string, opts GraphExportOptions) (*GraphExport, error) {
+	idx, err := LoadIndex(vaultPath)
+	if err != nil {
+		return nil, err
+	}
+	return idx.ExportGraph(opts), nil
+}
+
+// FindUnlinkedMentions returns the plain-text mentions of a note's name and aliases in other notes.
+func (m *Note) FindUnlinkedMentions(vaultPath string, noteName string) ([]Mention, error) {
+	idx, relPath, err := findIndexedNote(vaultPath, noteName)
+	if err != nil {
+		return nil, err
+	}
+	return idx.UnlinkedMentions(relPath), nil
+}
+
+// LinkMentions turns unlinked mentions of a note into wikilinks to it and returns those linked.
+func (m *Note) LinkMentions(vaultPath string, noteName string, mentions []Mention) ([]Mention, error) {
+	idx, relPath, err := findIndexedNote(vaultPath, noteName)
+	if err != nil {
+		return nil, err
+	}
+	var linked []Mention
+	err = m.Transact(func() error {
+		linked, err = idx.linkMentions(relPath, mentions, diskFiles{idx, m.tx})
 		if err != nil {
 			return err
 		}
-		if !d.IsDir() && strings.HasSuffix(d.Name(), ".md") {
-			relPath, err := filepath.Rel(vaultPath, path)
-			if err != nil {
-				return err
-			}
-			notes = append(notes, relPath)
-		}
+		m.tx.OnCommit(func() { _ = idx.Save() })
 		return nil
 	})
 	if err != nil {
 		return nil, err
 	}
-	return notes, nil
+	return linked, nil
 }
 
-func (m *Note) SearchNotesWithSnippets(vaultPath string, query string) ([]NoteMatch, error) {
-	var matches []NoteMatch
-	queryLower := strings.ToLower(query)
+func (m *Note) GetNotesList(vaultPath string) ([]string, error) {
+	idx, err := LoadIndex(vaultPath)
+	if err != nil {
+		return nil, err
+	}
+	return idx.Notes(), nil
+}
 
-	err := filepath.WalkDir(vaultPath, func(path string, d fs.DirEntry, err error) error {
+// SearchNotesWithSnippets evaluates a query written in Obsidian's search syntax, ranks the
+// matching notes with BM25 over their title, headings and body, and returns the matching
+// lines of each note in rank order. With opts.Regex the query is a regular expression
+// matched against every line instead.
+func (m *Note) SearchNotesWithSnippets(vaultPath string, query string, opts SearchOptions) ([]NoteMatch, error) {
+	sidx, err := LoadSearchIndex(vaultPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []rankedNote
+	var match lineMatcher
+	if opts.Regex {
+		re, err := compileSearchRegex(query, opts)
 		if err != nil {
-			return err
+			return nil, err
 		}
-		if !d.IsDir() && strings.HasSuffix(d.Name(), ".md") {
-			relPath, err := filepath.Rel(vaultPath, path)
-			if err != nil {
-				return err
-			}
-
-			fileNameMatches := strings.Contains(strings.ToLower(relPath), queryLower)
-			var hasContentMatch bool
-
-			// Check file size to avoid reading very large files (>10MB)
-			if info, err := d.Info(); err == nil && info.Size() < 10*1024*1024 {
-				content, err := os.ReadFile(path)
-				if err == nil {
-					lines := strings.Split(string(content), "\n")
-					for lineNum, line := range lines {
-						if strings.Contains(strings.ToLower(line), queryLower) {
-							hasContentMatch = true
-							matchLine := strings.TrimSpace(line)
-							if len(matchLine) > 80 {
-								// Find the query position and center around it
-								queryPos := strings.Index(strings.ToLower(matchLine), queryLower)
-								if queryPos != -1 {
-									start := queryPos - 20
-									end := queryPos + len(query) + 20
-									if start < 0 {
-										start = 0
-									}
-									if end > len(matchLine) {
-										end = len(matchLine)
-									}
-									if start > 0 {
-										matchLine = "..." + matchLine[start:]
-									}
-									if end < len(strings.TrimSpace(line)) {
-										matchLine = matchLine[:end-start] + "..."
-									}
-								} else {
-									matchLine = matchLine[:80] + "..."
-								}
-							}
-
-							matches = append(matches, NoteMatch{
-								FilePath:   relPath,
-								LineNumber: lineNum + 1,
-								MatchLine:  matchLine,
-							})
-						}
-					}
-				}
-			}
-
-			// Only add filename match if there are no content matches
-			if fileNameMatches && !hasContentMatch {
-				matches = append(matches, NoteMatch{
-					FilePath:   relPath,
-					LineNumber: 0,
-					MatchLine:  fmt.Sprintf("(filename match: %s)", filepath.Base(relPath)),
-				})
-			}
+		match = regexLineMatcher(re, opts)
+		results = sidx.rankLines(match, opts)
+	} else {
+		parsed, err := parseSearchQuery(query)
+		if err != nil {
+			return nil, err
 		}
-		return nil
-	})
+		results = sidx.rank(parsed, opts)
+		match = queryLineMatcher(parsed, opts)
+	}
 
-	if err != nil {
-		return nil, err
+	var matches []NoteMatch
+	for _, result := range results {
+		matches = append(matches, sidx.noteSnippets(result, match)...)
 	}
 	return matches, nil
 }
@@ -325,6 +465,11 @@ func findMatchingLines(content []byte, patternsLower [][]byte) []NoteMatch {
 func (m *Note) FindBacklinks(vaultPath, noteName string) ([]NoteMatch, error) {
 	noteName = RemoveMdSuffix(noteName)
 
+	idx, err := LoadIndex(vaultPath)
+	if err != nil {
+		return nil, err
+	}
+
 	// Generate patterns and convert to lowercase bytes once
 	patterns := GenerateBacklinkSearchPatterns(noteName)
 	patternsLower := make([][]byte, len(patterns))
@@ -335,58 +480,37 @@ func (m *Note) FindBacklinks(vaultPath, noteName string) ([]NoteMatch, error) {
 	var matches []NoteMatch
 	fileModTimes := make(map[string]int64)
 
-	err := filepath.WalkDir(vaultPath, func(path string, d fs.DirEntry, err error) error {
-		if err != nil {
-			return err
-		}
-		if d.IsDir() || !strings.HasSuffix(d.Name(), ".md") {
-			return nil
-		}
-
-		relPath, err := filepath.Rel(vaultPath, path)
-		if err != nil {
-			return err
-		}
-
+	// Only notes whose indexed links point at the note need to be read
+	for _, relPath := range idx.LinkCandidates(noteName) {
 		// Skip the note itself (normalize for comparison)
 		if RemoveMdSuffix(normalizePathSeparators(relPath)) == noteName {
-			return nil
+			continue
 		}
 
-		info, err := d.Info()
-		if err != nil {
-			return nil
-		}
-		if info.Size() > maxFileSizeBytes {
-			fmt.Fprintf(os.Stderr, "Skipping file %s: size %d bytes exceeds limit %d bytes\n", relPath, info.Size(), maxFileSizeBytes)
-			return nil
+		entry := idx.Entries[relPath]
+		if entry.Size > maxFileSizeBytes {
+			fmt.Fprintf(os.Stderr, "Skipping file %s: size %d bytes exceeds limit %d bytes\n", relPath, entry.Size, maxFileSizeBytes)
+			continue
 		}
 
-		content, err := os.ReadFile(path)
+		content, err := os.ReadFile(filepath.Join(idx.VaultPath, relPath))
 		if err != nil {
-			return nil
+			continue
 		}
 
 		// Quick check: skip file if it doesn't contain any pattern
 		contentLower := bytes.ToLower(content)
 		if !containsAnyPattern(contentLower, patternsLower) {
-			return nil
+			continue
 		}
 
 		// Find matching lines
-		modTime := info.ModTime().UnixNano()
 		fileMatches := findMatchingLines(content, patternsLower)
 		for i := range fileMatches {
 			fileMatches[i].FilePath = relPath
-			fileModTimes[relPath] = modTime
+			fileModTimes[relPath] = entry.ModTime
 		}
 		matches = append(matches, fileMatches...)
-
-		return nil
-	})
-
-	if err != nil {
-		return nil, err
 	}
 
 	sort.Slice(matches, func(i, j int) bool {
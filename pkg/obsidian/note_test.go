@@ -2,6 +2,7 @@ package obsidian_test
 
 import (
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"testing"
@@ -130,6 +131,7 @@ func TestNote_GetContents(t *testing.T) {
 		contents, err := noteManager.GetContents("path", "non-existent-note")
 		// Assert
 		assert.Equal(t, obsidian.NoteDoesNotExistError, err.Error(), "Expected error while deleting non-existent note")
+		assert.ErrorIs(t, err, obsidian.ErrNoteNotFound)
 		assert.Equal(t, contents, "")
 
 	})
@@ -280,6 +282,8 @@ func TestUpdateNoteLinks(t *testing.T) {
 		err := noteManager.UpdateLinks(tmpDir, "oldNote", "newNote")
 		// Assert
 		assert.Equal(t, err.Error(), obsidian.VaultWriteError)
+		assert.ErrorIs(t, err, obsidian.ErrWrite)
+		assert.ErrorIs(t, err, fs.ErrPermission)
 	})
 }
 
@@ -305,6 +309,47 @@ func TestUpdateNoteLinks_PathBasedWikilinks(t *testing.T) {
 	})
 }
 
+func TestUpdateNoteLinks_Code(t *testing.T) {
+	t.Run("Links inside code are left alone whether or not the note has other links", func(t *testing.T) {
+		// Arrange
+		tmpDir := t.TempDir()
+		code := "`[[oldNote]]`\n```\n[[oldNote]]\n```\n"
+		writeVaultFiles(t, tmpDir, map[string]string{
+			"linked.md": "See [[oldNote]]\n" + code,
+			"code.md":   code,
+		})
+		noteManager := obsidian.Note{}
+
+		// Act
+		err := noteManager.UpdateLinks(tmpDir, "oldNote", "newNote")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "See [[newNote]]\n"+code, readVaultFile(t, filepath.Join(tmpDir, "linked.md")))
+		assert.Equal(t, code, readVaultFile(t, filepath.Join(tmpDir, "code.md")))
+	})
+}
+
+func TestUpdateNoteLinks_SharedName(t *testing.T) {
+	t.Run("Links by name point at the path of a note whose name is shared", func(t *testing.T) {
+		// Arrange
+		tmpDir := t.TempDir()
+		writeVaultFiles(t, tmpDir, map[string]string{
+			"a/plan.md": "",
+			"b/plan.md": "",
+			"index.md":  "See [[plna]] and [[plna|the plan]]",
+		})
+		noteManager := obsidian.Note{}
+
+		// Act
+		err := noteManager.UpdateLinks(tmpDir, "plna", filepath.Join("b", "plan.md"))
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "See [[b/plan]] and [[b/plan|the plan]]", readVaultFile(t, filepath.Join(tmpDir, "index.md")))
+	})
+}
+
 func TestUpdateNoteLinks_MarkdownLinks(t *testing.T) {
 	t.Run("Update markdown links", func(t *testing.T) {
 		tmpDir := t.TempDir()
@@ -361,6 +406,30 @@ func TestUpdateNoteLinks_MixedFormats(t *testing.T) {
 	})
 }
 
+func TestNote_UnlinkNote(t *testing.T) {
+	t.Run("Turns links to the note into text", func(t *testing.T) {
+		// Arrange
+		tmpDir := t.TempDir()
+		writeVaultFiles(t, tmpDir, map[string]string{
+			"a.md":         "See [[oldNote|the old note]] and [[other]]",
+			"b.md":         "See [here](oldNote.md)",
+			"unrelated.md": "Nothing to see",
+			"oldNote.md":   "content",
+			"other.md":     "content",
+		})
+		noteManager := obsidian.Note{}
+
+		// Act
+		err := noteManager.UnlinkNote(tmpDir, "oldNote")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "See the old note and [[other]]", readVaultFile(t, filepath.Join(tmpDir, "a.md")))
+		assert.Equal(t, "See here", readVaultFile(t, filepath.Join(tmpDir, "b.md")))
+		assert.Equal(t, "Nothing to see", readVaultFile(t, filepath.Join(tmpDir, "unrelated.md")))
+	})
+}
+
 func TestUpdateLinks_PreservesTimestamps(t *testing.T) {
 	t.Run("Only writes files with actual link changes", func(t *testing.T) {
 		// Arrange
@@ -503,7 +572,7 @@ func TestSearchNotesWithSnippets(t *testing.T) {
 
 		// Act
 		note := obsidian.Note{}
-		matches, err := note.SearchNotesWithSnippets(fullVaultPath, "test")
+		matches, err := note.SearchNotesWithSnippets(fullVaultPath, "test", obsidian.SearchOptions{})
 
 		// Assert
 		assert.NoError(t, err)
@@ -535,7 +604,7 @@ func TestSearchNotesWithSnippets(t *testing.T) {
 
 		// Act
 		note := obsidian.Note{}
-		matches, err := note.SearchNotesWithSnippets(fullVaultPath, "test")
+		matches, err := note.SearchNotesWithSnippets(fullVaultPath, "test", obsidian.SearchOptions{})
 
 		// Assert
 		assert.NoError(t, err)
@@ -560,7 +629,7 @@ func TestSearchNotesWithSnippets(t *testing.T) {
 
 		// Act
 		note := obsidian.Note{}
-		matches, err := note.SearchNotesWithSnippets(fullVaultPath, "test")
+		matches, err := note.SearchNotesWithSnippets(fullVaultPath, "test", obsidian.SearchOptions{})
 
 		// Assert
 		assert.NoError(t, err)
@@ -585,7 +654,7 @@ func TestSearchNotesWithSnippets(t *testing.T) {
 
 		// Act
 		note := obsidian.Note{}
-		matches, err := note.SearchNotesWithSnippets(fullVaultPath, "nonexistent")
+		matches, err := note.SearchNotesWithSnippets(fullVaultPath, "nonexistent", obsidian.SearchOptions{})
 
 		// Assert
 		assert.NoError(t, err)
@@ -607,7 +676,7 @@ func TestSearchNotesWithSnippets(t *testing.T) {
 
 		// Act
 		note := obsidian.Note{}
-		matches, err := note.SearchNotesWithSnippets(fullVaultPath, "test")
+		matches, err := note.SearchNotesWithSnippets(fullVaultPath, "test", obsidian.SearchOptions{})
 
 		// Assert
 		assert.NoError(t, err)
@@ -0,0 +1,123 @@
+package obsidian
+
+import (
+	"errors"
+	"path"
+	"sort"
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// foldName returns the form note names are compared in: lower case and in Unicode
+// normalization form C, so a name typed as "café" matches a file name stored
+// decomposed, as macOS does.
+func foldName(name string) string {
+	return strings.ToLower(norm.NFC.String(name))
+}
+
+// ResolveNote returns the vault-relative path of the note a name refers to, the
+// way Obsidian resolves link targets: an exact vault path first, then a unique
+// file name or path suffix. Names are compared case-insensitively and after
+// Unicode normalization, preferring a note whose path matches exactly. When
+// several notes share the name, the one with the shortest path relative to the
+// note at source wins, as for a link written in that note. Names given without
+// a source, like those typed on the command line, must be unique: otherwise an
+// *AmbiguousNoteError lists the matching notes.
+func (idx *VaultIndex) ResolveNote(name string, source string) (string, error) {
+	target := strings.TrimPrefix(normalizePathSeparators(name), "./")
+	target = RemoveMdSuffix(strings.TrimPrefix(target, "/"))
+	if target == "" {
+		return "", ErrNoteNotFound
+	}
+
+	lookup := idx.noteLookup()
+	if relPath, ok := lookup.exact[target]; ok {
+		return relPath, nil
+	}
+	key := foldName(target)
+	if relPath, ok := lookup.byPath[key]; ok {
+		return relPath, nil
+	}
+
+	var candidates []string
+	for _, relPath := range lookup.byName[path.Base(key)] {
+		notePath := foldName(RemoveMdSuffix(normalizePathSeparators(relPath)))
+		if strings.HasSuffix(notePath, "/"+key) {
+			candidates = append(candidates, relPath)
+		}
+	}
+	switch {
+	case len(candidates) == 0:
+		return "", ErrNoteNotFound
+	case len(candidates) == 1:
+		return candidates[0], nil
+	case source != "":
+		return closestNote(source, candidates), nil
+	}
+	return "", ambiguousNote(name, candidates)
+}
+
+// ResolveNoteName finds the note a name typed on the command line refers to. It
+// resolves the name like ResolveNote without a linking note and falls back to the
+// aliases in the frontmatter of the notes, so "js" finds a note with
+// "aliases: [JS]".
+func (idx *VaultIndex) ResolveNoteName(name string) (string, error) {
+	relPath, err := idx.ResolveNote(name, "")
+	if !errors.Is(err, ErrNoteNotFound) {
+		return relPath, err
+	}
+
+	notes := idx.noteLookup().aliases[foldName(strings.TrimSpace(name))]
+	switch len(notes) {
+	case 0:
+		return "", ErrNoteNotFound
+	case 1:
+		return notes[0], nil
+	}
+	return "", ambiguousNote(name, notes)
+}
+
+func ambiguousNote(name string, candidates []string) *AmbiguousNoteError {
+	names := make([]string, len(candidates))
+	for i, relPath := range candidates {
+		names[i] = normalizePathSeparators(relPath)
+	}
+	sort.Strings(names)
+	return &AmbiguousNoteError{Name: name, Candidates: names}
+}
+
+// closestNote returns the candidate with the shortest path relative to the note
+// at source, the first one in path order if several are equally close.
+func closestNote(source string, candidates []string) string {
+	sorted := append([]string{}, candidates...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return normalizePathSeparators(sorted[i]) < normalizePathSeparators(sorted[j])
+	})
+
+	sourceDir := path.Dir(normalizePathSeparators(source))
+	closest, shortest := "", -1
+	for _, relPath := range sorted {
+		distance := relativeDistance(sourceDir, normalizePathSeparators(relPath))
+		if shortest < 0 || distance < shortest {
+			closest, shortest = relPath, distance
+		}
+	}
+	return closest
+}
+
+// relativeDistance counts the path segments of the relative path from a folder
+// to a file, both vault-relative with forward slashes.
+func relativeDistance(dir string, file string) int {
+	var dirParts []string
+	if dir != "." && dir != "" {
+		dirParts = strings.Split(dir, "/")
+	}
+	fileParts := strings.Split(file, "/")
+
+	common := 0
+	for common < len(dirParts) && common < len(fileParts)-1 && dirParts[common] == fileParts[common] {
+		common++
+	}
+	return len(dirParts) - common + len(fileParts) - common
+}
@@ -0,0 +1,134 @@
+package obsidian_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVaultIndex_ResolveNote(t *testing.T) {
+	vaultDir := t.TempDir()
+	writeVaultFiles(t, vaultDir, map[string]string{
+		"projects/README.md":      "",
+		"archive/README.md":       "",
+		"archive/old/Plan.md":     "",
+		"Unique.md":               "",
+		"projects/notes/index.md": "",
+	})
+	idx, err := obsidian.LoadIndex(vaultDir)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		testName string
+		name     string
+		source   string
+		want     string
+	}{
+		{"Exact vault path", "projects/README", "", filepath.Join("projects", "README.md")},
+		{"Exact vault path with extension", "archive/README.md", "", filepath.Join("archive", "README.md")},
+		{"Unique file name", "Plan", "", filepath.Join("archive", "old", "Plan.md")},
+		{"Path suffix", "old/Plan", "", filepath.Join("archive", "old", "Plan.md")},
+		{"Different case", "unique", "", "Unique.md"},
+		{"Closest to the linking note", "README", filepath.Join("projects", "notes", "index.md"), filepath.Join("projects", "README.md")},
+		{"Closest from another folder", "README", filepath.Join("archive", "old", "Plan.md"), filepath.Join("archive", "README.md")},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testName, func(t *testing.T) {
+			// Act
+			got, err := idx.ResolveNote(test.name, test.source)
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+
+	t.Run("Ambiguous name without a linking note", func(t *testing.T) {
+		// Act
+		_, err := idx.ResolveNote("README", "")
+
+		// Assert
+		var ambiguous *obsidian.AmbiguousNoteError
+		if assert.ErrorAs(t, err, &ambiguous) {
+			assert.Equal(t, []string{"archive/README.md", "projects/README.md"}, ambiguous.Candidates)
+		}
+	})
+
+	t.Run("Note not found", func(t *testing.T) {
+		// Act
+		_, err := idx.ResolveNote("missing", "")
+
+		// Assert
+		assert.ErrorIs(t, err, obsidian.ErrNoteNotFound)
+	})
+}
+
+func TestVaultIndex_ResolveNoteName(t *testing.T) {
+	vaultDir := t.TempDir()
+	writeVaultFiles(t, vaultDir, map[string]string{
+		"dev/JavaScript.md": "---\naliases: [JS, ECMAScript]\n---\n",
+		"dev/TypeScript.md": "---\naliases: TS\n---\n",
+		"Java.md":           "---\naliases: [Coffee]\n---\n",
+		"Beans.md":          "---\nalias: Coffee\n---\n",
+		"Cafe\u0301.md":     "",
+	})
+	idx, err := obsidian.LoadIndex(vaultDir)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		testName string
+		name     string
+		want     string
+	}{
+		{"File name first", "java", "Java.md"},
+		{"Alias", "JS", filepath.Join("dev", "JavaScript.md")},
+		{"Alias in another case", "js", filepath.Join("dev", "JavaScript.md")},
+		{"Alias given as a string", "ts", filepath.Join("dev", "TypeScript.md")},
+		{"Composed name of a decomposed file name", "caf\u00e9", "Cafe\u0301.md"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testName, func(t *testing.T) {
+			// Act
+			got, err := idx.ResolveNoteName(test.name)
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+
+	t.Run("Alias shared by several notes", func(t *testing.T) {
+		// Act
+		_, err := idx.ResolveNoteName("coffee")
+
+		// Assert
+		var ambiguous *obsidian.AmbiguousNoteError
+		if assert.ErrorAs(t, err, &ambiguous) {
+			assert.Equal(t, []string{"Beans.md", "Java.md"}, ambiguous.Candidates)
+		}
+	})
+}
+
+func TestNote_AmbiguousNoteName(t *testing.T) {
+	t.Run("GetContents and SetContents refuse an ambiguous name", func(t *testing.T) {
+		// Arrange
+		vaultDir := t.TempDir()
+		writeVaultFiles(t, vaultDir, map[string]string{"projects/README.md": "projects", "archive/README.md": "archive"})
+		noteManager := obsidian.Note{}
+
+		// Act
+		_, getErr := noteManager.GetContents(vaultDir, "README")
+		setErr := noteManager.SetContents(vaultDir, "README", "changed")
+		contents, err := noteManager.GetContents(vaultDir, "archive/README")
+
+		// Assert
+		var ambiguous *obsidian.AmbiguousNoteError
+		assert.ErrorAs(t, getErr, &ambiguous)
+		assert.ErrorAs(t, setErr, &ambiguous)
+		assert.NoError(t, err)
+		assert.Equal(t, "archive", contents)
+		assert.Equal(t, "projects", readVaultFile(t, filepath.Join(vaultDir, "projects", "README.md")))
+	})
+}
@@ -0,0 +1,415 @@
+package obsidian
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/frontmatter"
+)
+
+// SearchOptions controls how SearchNotesWithSnippets matches and ranks notes.
+type SearchOptions struct {
+	// Limit is the maximum number of notes returned, 0 returns every match
+	Limit int
+	// MetadataFilters keeps only notes whose frontmatter matches every key=value pair
+	MetadataFilters map[string]string
+	// Regex treats the query as a regular expression matched against each line and file name
+	Regex bool
+	// CaseSensitive matches the exact case of the query instead of ignoring it
+	CaseSensitive bool
+	// Word only matches whole words instead of substrings of longer words
+	Word bool
+}
+
+// lineMatcher returns the byte span of the first match in a line of a note.
+type lineMatcher func(line string) (start int, end int, ok bool)
+
+const (
+	bm25K1             = 1.2
+	bm25B              = 0.75
+	partialMatchWeight = 0.5
+	maxSnippetLength   = 80
+	snippetContext     = 20
+)
+
+// searchFieldWeights boosts matches in the title and headings over the body.
+var searchFieldWeights = [searchFieldCount]float64{3, 2, 1}
+
+// queryTerm is the text of a word or quoted phrase of a search query.
+// Words that tokenize into several tokens, like "e-mail", are treated as phrases.
+type queryTerm struct {
+	tokens []string
+	phrase bool
+}
+
+// rankedNote is a note that matched a query together with its BM25 score.
+type rankedNote struct {
+	path  string
+	score float64
+}
+
+// expandToken returns the indexed terms a query token matches with their weight.
+// Obsidian matches substrings, so terms containing the token match too, with a lower weight.
+func (sidx *SearchIndex) expandToken(token string, exact bool) map[string]float64 {
+	expansions := make(map[string]float64)
+	if _, ok := sidx.postings[token]; ok {
+		expansions[token] = 1
+	}
+	if exact {
+		return expansions
+	}
+	for term := range sidx.postings {
+		if term != token && strings.Contains(term, token) {
+			expansions[term] = partialMatchWeight
+		}
+	}
+	return expansions
+}
+
+// termDocuments returns the set of notes containing the term in any field.
+func (sidx *SearchIndex) termDocuments(term queryTerm, expansions []map[string]float64) map[string]bool {
+	var docs map[string]bool
+	for _, expansion := range expansions {
+		tokenDocs := make(map[string]bool)
+		for indexed := range expansion {
+			for _, relPath := range sidx.postings[indexed] {
+				tokenDocs[relPath] = true
+			}
+		}
+		if docs == nil {
+			docs = tokenDocs
+			continue
+		}
+		for relPath := range docs {
+			if !tokenDocs[relPath] {
+				delete(docs, relPath)
+			}
+		}
+	}
+
+	if term.phrase {
+		for relPath := range docs {
+			if !sidx.containsTerm(relPath, term.tokens, true, SearchOptions{}) {
+				delete(docs, relPath)
+			}
+		}
+	}
+	return docs
+}
+
+// containsTerm checks whether the title or content of a note contains the query tokens.
+func (sidx *SearchIndex) containsTerm(relPath string, want []string, phrase bool, opts SearchOptions) bool {
+	title := RemoveMdSuffix(filepath.Base(relPath))
+	if _, _, ok := findTerm(title, tokenize(title), want, phrase, opts); ok {
+		return true
+	}
+	content, err := os.ReadFile(filepath.Join(sidx.VaultPath, relPath))
+	if err != nil {
+		return false
+	}
+	text := string(content)
+	_, _, ok := findTerm(text, tokenize(text), want, phrase, opts)
+	return ok
+}
+
+// tokenMatches compares a token of text with a query token. Tokens are compared in
+// their original case when the search is case-sensitive, and must be equal in word mode.
+func tokenMatches(text string, token searchToken, want string, exact bool, opts SearchOptions) bool {
+	got := token.Text
+	if opts.CaseSensitive {
+		got = text[token.Start:token.End]
+	}
+	if exact || opts.Word {
+		return got == want
+	}
+	return strings.Contains(got, want)
+}
+
+// findTerm returns the span of the first occurrence of a word or phrase in the tokens of text.
+// Phrases must appear as consecutive whole tokens.
+func findTerm(text string, tokens []searchToken, want []string, phrase bool, opts SearchOptions) (int, int, bool) {
+	if len(want) == 0 {
+		return 0, 0, false
+	}
+	for i := 0; i+len(want) <= len(tokens); i++ {
+		found := true
+		for j, word := range want {
+			if !tokenMatches(text, tokens[i+j], word, phrase, opts) {
+				found = false
+				break
+			}
+		}
+		if found {
+			return tokens[i].Start, tokens[i+len(want)-1].End, true
+		}
+	}
+	return 0, 0, false
+}
+
+// rank returns the notes matching the query, best BM25 score first. Only words and
+// phrases matched against the note content contribute to the score, so queries made
+// of operators like tag: or path: alone return the matching notes in vault order.
+func (sidx *SearchIndex) rank(query *searchQuery, opts SearchOptions) []rankedNote {
+	if query.root == nil {
+		return nil
+	}
+
+	for _, term := range query.terms {
+		if !term.matchesContent() {
+			continue
+		}
+		term.expansions = nil
+		for _, token := range term.term.tokens {
+			term.expansions = append(term.expansions, sidx.expandToken(token, term.term.phrase || opts.Word))
+		}
+		term.docs = sidx.termDocuments(term.term, term.expansions)
+		if opts.CaseSensitive && term.scope == scopeDefault {
+			// The index is lower case, so check the exact case in the notes it found
+			for relPath := range term.docs {
+				if !sidx.containsTerm(relPath, term.tokensFor(opts), term.term.phrase, opts) {
+					delete(term.docs, relPath)
+				}
+			}
+		}
+	}
+
+	var results []rankedNote
+	for _, relPath := range sidx.vault.order {
+		entry := sidx.vault.Entries[relPath]
+		candidate := &searchCandidate{sidx: sidx, relPath: relPath, entry: entry, opts: opts}
+		if !candidate.matches(query.root) {
+			continue
+		}
+		if len(opts.MetadataFilters) > 0 && !frontmatter.MatchesFilter(entry.Frontmatter, opts.MetadataFilters) {
+			continue
+		}
+
+		score := 0.0
+		for _, term := range query.terms {
+			if !term.matchesContent() || term.negated {
+				continue
+			}
+			for _, expansion := range term.expansions {
+				score += sidx.bm25(relPath, expansion, len(term.docs))
+			}
+		}
+		results = append(results, rankedNote{path: relPath, score: score})
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].score > results[j].score
+	})
+	if opts.Limit > 0 && len(results) > opts.Limit {
+		results = results[:opts.Limit]
+	}
+	return results
+}
+
+// bm25 scores one query token against a note, combining the title, headings and
+// body fields with their weights before saturating the term frequency (BM25F).
+func (sidx *SearchIndex) bm25(relPath string, expansion map[string]float64, docFrequency int) float64 {
+	doc, ok := sidx.Documents[relPath]
+	if !ok || docFrequency == 0 {
+		return 0
+	}
+
+	weightedFrequency := 0.0
+	for field, frequencies := range doc.fields() {
+		if len(frequencies) == 0 || sidx.averages[field] == 0 {
+			continue
+		}
+		frequency := 0.0
+		for term, weight := range expansion {
+			frequency += weight * float64(frequencies[term])
+		}
+		if frequency == 0 {
+			continue
+		}
+		lengthNorm := 1 - bm25B + bm25B*float64(fieldLength(frequencies))/sidx.averages[field]
+		weightedFrequency += searchFieldWeights[field] * frequency / lengthNorm
+	}
+
+	total := float64(len(sidx.Documents))
+	idf := math.Log(1 + (total-float64(docFrequency)+0.5)/(float64(docFrequency)+0.5))
+	return idf * weightedFrequency / (bm25K1 + weightedFrequency)
+}
+
+// rankLines returns the notes with a file name or line matched by the matcher, scored
+// by the number of matching lines. It is used for regular expression searches, which
+// cannot be answered from the token index.
+func (sidx *SearchIndex) rankLines(match lineMatcher, opts SearchOptions) []rankedNote {
+	var results []rankedNote
+	for _, relPath := range sidx.vault.order {
+		entry := sidx.vault.Entries[relPath]
+		if len(opts.MetadataFilters) > 0 && !frontmatter.MatchesFilter(entry.Frontmatter, opts.MetadataFilters) {
+			continue
+		}
+
+		score := 0.0
+		if _, _, ok := match(filepath.Base(relPath)); ok {
+			score++
+		}
+		if !entry.Unreadable && entry.Size <= maxFileSizeBytes {
+			if content, err := os.ReadFile(filepath.Join(sidx.VaultPath, relPath)); err == nil {
+				for _, line := range searchLines(content) {
+					if _, _, ok := match(line); ok {
+						score++
+					}
+				}
+			}
+		}
+		if score > 0 {
+			results = append(results, rankedNote{path: relPath, score: score})
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].score > results[j].score
+	})
+	if opts.Limit > 0 && len(results) > opts.Limit {
+		results = results[:opts.Limit]
+	}
+	return results
+}
+
+// queryLineMatcher finds the earliest word of the query in a line, so snippets are
+// centred on it. Words of phrases are matched on their own.
+func queryLineMatcher(query *searchQuery, opts SearchOptions) lineMatcher {
+	return func(line string) (int, int, bool) {
+		tokens := tokenize(line)
+		start, end, found := 0, 0, false
+		for _, term := range query.terms {
+			if !term.matchesContent() || term.negated {
+				continue
+			}
+			for _, want := range term.tokensFor(opts) {
+				s, e, ok := findTerm(line, tokens, []string{want}, false, opts)
+				if ok && (!found || s < start) {
+					start, end, found = s, e, true
+				}
+			}
+		}
+		return start, end, found
+	}
+}
+
+// compileSearchRegex compiles a regular expression query, ignoring case unless
+// the search is case-sensitive.
+func compileSearchRegex(pattern string, opts SearchOptions) (*regexp.Regexp, error) {
+	if !opts.CaseSensitive {
+		pattern = "(?i)" + pattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", SearchRegexError, err)
+	}
+	return re, nil
+}
+
+// regexLineMatcher returns the first non-empty match of the expression in a line.
+// In word mode matches must not start or end inside a word.
+func regexLineMatcher(re *regexp.Regexp, opts SearchOptions) lineMatcher {
+	return func(line string) (int, int, bool) {
+		for _, loc := range re.FindAllStringIndex(line, -1) {
+			if loc[0] == loc[1] {
+				continue
+			}
+			if !opts.Word || isWordSpan(line, loc[0], loc[1]) {
+				return loc[0], loc[1], true
+			}
+		}
+		return 0, 0, false
+	}
+}
+
+func isWordSpan(text string, start, end int) bool {
+	if before, _ := utf8.DecodeLastRuneInString(text[:start]); start > 0 && isTokenRune(before) {
+		return false
+	}
+	if after, _ := utf8.DecodeRuneInString(text[end:]); end < len(text) && isTokenRune(after) {
+		return false
+	}
+	return true
+}
+
+// searchLines splits a note into the lines that are matched and shown in snippets,
+// without the indentation and line endings around them, so ranking and snippets
+// agree on which lines match.
+func searchLines(content []byte) []string {
+	lines := strings.Split(string(content), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+	return lines
+}
+
+// noteSnippets returns a match for every line of the note accepted by the matcher,
+// or a single filename match when only the title matched.
+func (sidx *SearchIndex) noteSnippets(result rankedNote, match lineMatcher) []NoteMatch {
+	var matches []NoteMatch
+
+	content, err := os.ReadFile(filepath.Join(sidx.VaultPath, result.path))
+	if err == nil && int64(len(content)) < maxFileSizeBytes {
+		for lineNum, line := range searchLines(content) {
+			start, end, ok := match(line)
+			if !ok {
+				continue
+			}
+			matches = append(matches, NoteMatch{
+				FilePath:   result.path,
+				LineNumber: lineNum + 1,
+				MatchLine:  formatSnippet(line, start, end),
+				Score:      result.score,
+			})
+		}
+	}
+
+	if len(matches) == 0 {
+		matches = append(matches, NoteMatch{
+			FilePath:   result.path,
+			LineNumber: 0,
+			MatchLine:  fmt.Sprintf("(filename match: %s)", filepath.Base(result.path)),
+			Score:      result.score,
+		})
+	}
+	return matches
+}
+
+// formatSnippet shortens lines longer than maxSnippetLength to the text around the
+// match between start and end, marking cut text with an ellipsis.
+func formatSnippet(line string, start, end int) string {
+	if len(line) <= maxSnippetLength {
+		return line
+	}
+
+	from := start - snippetContext
+	if from < 0 {
+		from = 0
+	}
+	to := end + snippetContext
+	if to > len(line) {
+		to = len(line)
+	}
+	// Never cut a multi-byte character in half
+	for from > 0 && !utf8.RuneStart(line[from]) {
+		from--
+	}
+	for to < len(line) && !utf8.RuneStart(line[to]) {
+		to++
+	}
+
+	snippet := line[from:to]
+	if from > 0 {
+		snippet = "..." + snippet
+	}
+	if to < len(line) {
+		snippet += "..."
+	}
+	return snippet
+}
@@ -0,0 +1,283 @@
+package obsidian
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// SearchIndexVersion is bumped whenever the on-disk full-text index format changes.
+const SearchIndexVersion = 1
+
+// SearchDocument holds the term frequencies of one note, split by field.
+type SearchDocument struct {
+	ModTime  int64          `json:"mtime"`
+	Size     int64          `json:"size"`
+	Title    map[string]int `json:"title,omitempty"`
+	Headings map[string]int `json:"headings,omitempty"`
+	Body     map[string]int `json:"body,omitempty"`
+}
+
+// SearchIndex is an inverted index over note titles, headings and bodies.
+// It is stored next to the vault index and kept in sync with it by mtime and size.
+type SearchIndex struct {
+	Version   int                        `json:"version"`
+	VaultPath string                     `json:"vault_path"`
+	Documents map[string]*SearchDocument `json:"documents"`
+
+	vault    *VaultIndex
+	postings map[string][]string
+	averages [searchFieldCount]float64
+	dirty    bool
+}
+
+type searchField int
+
+const (
+	titleField searchField = iota
+	headingsField
+	bodyField
+	searchFieldCount
+)
+
+var (
+	searchIndexCacheMutex sync.Mutex
+	searchIndexCache      = make(map[string]*SearchIndex)
+)
+
+// LoadSearchIndex returns the full-text index of the vault, re-tokenizing notes
+// that changed since they were last indexed.
+func LoadSearchIndex(vaultPath string) (*SearchIndex, error) {
+	idx, err := LoadIndex(vaultPath)
+	if err != nil {
+		return nil, err
+	}
+
+	searchIndexCacheMutex.Lock()
+	sidx, ok := searchIndexCache[idx.VaultPath]
+	if !ok {
+		sidx = readSearchIndexFile(idx.VaultPath)
+		if sidx == nil {
+			sidx = newSearchIndex(idx.VaultPath)
+		}
+		searchIndexCache[idx.VaultPath] = sidx
+	}
+	searchIndexCacheMutex.Unlock()
+
+	sidx.vault = idx
+	sidx.refresh()
+	if sidx.dirty {
+		_ = sidx.Save()
+	}
+	return sidx, nil
+}
+
+// RebuildSearchIndex discards the cached full-text index and tokenizes every note again.
+func RebuildSearchIndex(vaultPath string) (*SearchIndex, error) {
+	idx, err := LoadIndex(vaultPath)
+	if err != nil {
+		return nil, err
+	}
+
+	sidx := newSearchIndex(idx.VaultPath)
+	sidx.vault = idx
+	sidx.refresh()
+	if err := sidx.Save(); err != nil {
+		return nil, err
+	}
+
+	searchIndexCacheMutex.Lock()
+	searchIndexCache[idx.VaultPath] = sidx
+	searchIndexCacheMutex.Unlock()
+	return sidx, nil
+}
+
+func newSearchIndex(vaultPath string) *SearchIndex {
+	return &SearchIndex{
+		Version:   SearchIndexVersion,
+		VaultPath: vaultPath,
+		Documents: make(map[string]*SearchDocument),
+		dirty:     true,
+	}
+}
+
+func searchIndexFile(vaultPath string) (string, error) {
+	indexFile, err := IndexFile(vaultPath)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(indexFile, filepath.Ext(indexFile)) + ".search.json", nil
+}
+
+func readSearchIndexFile(vaultPath string) *SearchIndex {
+	indexFile, err := searchIndexFile(vaultPath)
+	if err != nil {
+		return nil
+	}
+	content, err := os.ReadFile(indexFile)
+	if err != nil {
+		return nil
+	}
+
+	sidx := &SearchIndex{}
+	if err := json.Unmarshal(content, sidx); err != nil {
+		return nil
+	}
+	if sidx.Version != SearchIndexVersion || sidx.VaultPath != vaultPath || sidx.Documents == nil {
+		return nil
+	}
+	return sidx
+}
+
+// Save writes the full-text index to the CLI config directory.
+func (sidx *SearchIndex) Save() error {
+	indexFile, err := searchIndexFile(sidx.VaultPath)
+	if err != nil {
+		return err
+	}
+	if err := writeIndexFile(indexFile, sidx); err != nil {
+		return err
+	}
+	sidx.dirty = false
+	return nil
+}
+
+// refresh brings the documents in line with the vault index and rebuilds the postings.
+func (sidx *SearchIndex) refresh() {
+	for _, relPath := range sidx.vault.order {
+		entry := sidx.vault.Entries[relPath]
+		doc, ok := sidx.Documents[relPath]
+		if ok && doc.ModTime == entry.ModTime && doc.Size == entry.Size {
+			continue
+		}
+		sidx.Documents[relPath] = sidx.tokenizeDocument(entry)
+		sidx.dirty = true
+	}
+
+	for relPath := range sidx.Documents {
+		if _, ok := sidx.vault.Entries[relPath]; !ok {
+			delete(sidx.Documents, relPath)
+			sidx.dirty = true
+		}
+	}
+
+	sidx.buildPostings()
+}
+
+func (sidx *SearchIndex) tokenizeDocument(entry *IndexEntry) *SearchDocument {
+	doc := &SearchDocument{
+		ModTime: entry.ModTime,
+		Size:    entry.Size,
+		Title:   termFrequencies(RemoveMdSuffix(filepath.Base(entry.Path))),
+	}
+	if len(entry.Headings) > 0 {
+		doc.Headings = termFrequencies(strings.Join(entry.Headings, "\n"))
+	}
+
+	if entry.Unreadable || entry.Size > maxFileSizeBytes {
+		// Retry unreadable notes on the next refresh
+		if entry.Unreadable {
+			doc.ModTime = -1
+		}
+		return doc
+	}
+
+	content, err := os.ReadFile(filepath.Join(sidx.VaultPath, entry.Path))
+	if err != nil {
+		doc.ModTime = -1
+		return doc
+	}
+	doc.Body = termFrequencies(string(content))
+	return doc
+}
+
+func (sidx *SearchIndex) buildPostings() {
+	sidx.postings = make(map[string][]string)
+	var totals [searchFieldCount]float64
+
+	for _, relPath := range sidx.vault.order {
+		doc, ok := sidx.Documents[relPath]
+		if !ok {
+			continue
+		}
+		terms := make(map[string]bool)
+		for field, frequencies := range doc.fields() {
+			for term, count := range frequencies {
+				terms[term] = true
+				totals[field] += float64(count)
+			}
+		}
+		for term := range terms {
+			sidx.postings[term] = append(sidx.postings[term], relPath)
+		}
+	}
+
+	for field := range totals {
+		sidx.averages[field] = 0
+		if len(sidx.Documents) > 0 {
+			sidx.averages[field] = totals[field] / float64(len(sidx.Documents))
+		}
+	}
+}
+
+func (doc *SearchDocument) fields() [searchFieldCount]map[string]int {
+	return [searchFieldCount]map[string]int{doc.Title, doc.Headings, doc.Body}
+}
+
+// fieldLength returns the number of tokens in a field of the document.
+func fieldLength(frequencies map[string]int) int {
+	length := 0
+	for _, count := range frequencies {
+		length += count
+	}
+	return length
+}
+
+// searchToken is a lower-cased word together with its byte offsets in the source text.
+type searchToken struct {
+	Text  string
+	Start int
+	End   int
+}
+
+func isTokenRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsNumber(r) || unicode.Is(unicode.Mn, r)
+}
+
+// tokenize splits text into lower-cased runs of letters, digits and combining marks.
+// No stemming is applied, so the index works the same way for every language.
+func tokenize(text string) []searchToken {
+	var tokens []searchToken
+	start := -1
+	for i, r := range text {
+		if isTokenRune(r) {
+			if start == -1 {
+				start = i
+			}
+			continue
+		}
+		if start != -1 {
+			tokens = append(tokens, searchToken{Text: strings.ToLower(text[start:i]), Start: start, End: i})
+			start = -1
+		}
+	}
+	if start != -1 {
+		tokens = append(tokens, searchToken{Text: strings.ToLower(text[start:]), Start: start, End: len(text)})
+	}
+	return tokens
+}
+
+func termFrequencies(text string) map[string]int {
+	tokens := tokenize(text)
+	if len(tokens) == 0 {
+		return nil
+	}
+	frequencies := make(map[string]int)
+	for _, token := range tokens {
+		frequencies[token.Text]++
+	}
+	return frequencies
+}
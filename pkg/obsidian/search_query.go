@@ -0,0 +1,569 @@
+package obsidian
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// queryScope is the part of a note a search term is matched against.
+type queryScope int
+
+const (
+	scopeDefault queryScope = iota
+	scopeContent
+	scopeLine
+	scopeSection
+	scopePath
+	scopeFile
+	scopeTag
+)
+
+var queryOperators = map[string]queryScope{
+	"content": scopeContent,
+	"line":    scopeLine,
+	"section": scopeSection,
+	"path":    scopePath,
+	"file":    scopeFile,
+	"tag":     scopeTag,
+}
+
+// queryNode is a node of a parsed search query.
+type queryNode interface{}
+
+type andNode struct {
+	children []queryNode
+}
+
+type orNode struct {
+	children []queryNode
+}
+
+type notNode struct {
+	child queryNode
+}
+
+// termNode is a word or quoted phrase. Terms in the default, content, line and
+// section scopes are matched as tokens and contribute to the ranking.
+type termNode struct {
+	raw        string
+	term       queryTerm
+	caseTokens []string
+	scope      queryScope
+	negated    bool
+
+	expansions []map[string]float64
+	docs       map[string]bool
+}
+
+// scopeNode restricts its child to a part of the note, like path:(...) or line:(...).
+type scopeNode struct {
+	scope queryScope
+	child queryNode
+}
+
+// propertyNode matches frontmatter: [key] checks the key exists, [key:value] matches its value.
+type propertyNode struct {
+	key   string
+	value string
+}
+
+// searchQuery is a parsed query using Obsidian's search syntax.
+type searchQuery struct {
+	root  queryNode
+	terms []*termNode
+}
+
+type queryParser struct {
+	input []rune
+	pos   int
+	scope queryScope
+	depth int
+	terms []*termNode
+}
+
+// parseSearchQuery parses Obsidian's search syntax: words, "phrases", -negation, OR,
+// (groups), the path:, file:, tag:, content:, line: and section: operators and
+// [property:value] filters. Words separated by spaces must all match.
+func parseSearchQuery(query string) (*searchQuery, error) {
+	p := &queryParser{input: []rune(query)}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpaces()
+	if !p.eof() {
+		return nil, fmt.Errorf("%s: unexpected '%c'", SearchQueryParseError, p.peek())
+	}
+	return &searchQuery{root: root, terms: p.terms}, nil
+}
+
+func (p *queryParser) eof() bool {
+	return p.pos >= len(p.input)
+}
+
+func (p *queryParser) peek() rune {
+	if p.eof() {
+		return 0
+	}
+	return p.input[p.pos]
+}
+
+func (p *queryParser) skipSpaces() {
+	for !p.eof() && unicode.IsSpace(p.peek()) {
+		p.pos++
+	}
+}
+
+// atOr reports whether the parser is positioned at an OR keyword.
+func (p *queryParser) atOr() bool {
+	end := p.pos + 2
+	if end > len(p.input) || string(p.input[p.pos:end]) != "OR" {
+		return false
+	}
+	return end == len(p.input) || unicode.IsSpace(p.input[end]) || p.input[end] == '('
+}
+
+func (p *queryParser) parseOr() (queryNode, error) {
+	var children []queryNode
+	for {
+		node, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		if node != nil {
+			children = append(children, node)
+		}
+		p.skipSpaces()
+		if !p.atOr() {
+			break
+		}
+		p.pos += 2
+	}
+
+	if len(children) == 0 {
+		return nil, nil
+	}
+	if len(children) == 1 {
+		return children[0], nil
+	}
+	return &orNode{children: children}, nil
+}
+
+func (p *queryParser) parseAnd() (queryNode, error) {
+	var children []queryNode
+	for {
+		p.skipSpaces()
+		if p.eof() || p.peek() == ')' || p.atOr() {
+			break
+		}
+		node, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		if node != nil {
+			children = append(children, node)
+		}
+	}
+
+	if len(children) == 0 {
+		return nil, nil
+	}
+	if len(children) == 1 {
+		return children[0], nil
+	}
+	// Evaluate cheap index lookups before conditions that need the note content
+	sort.SliceStable(children, func(i, j int) bool {
+		return queryNodeCost(children[i]) < queryNodeCost(children[j])
+	})
+	return &andNode{children: children}, nil
+}
+
+func (p *queryParser) parseUnary() (queryNode, error) {
+	if p.peek() == '-' && p.pos+1 < len(p.input) && !unicode.IsSpace(p.input[p.pos+1]) {
+		p.pos++
+		p.depth++
+		child, err := p.parsePrimary()
+		p.depth--
+		if err != nil || child == nil {
+			return nil, err
+		}
+		return &notNode{child: child}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *queryParser) parsePrimary() (queryNode, error) {
+	switch p.peek() {
+	case '(':
+		p.pos++
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpaces()
+		if p.peek() != ')' {
+			return nil, fmt.Errorf("%s: missing closing parenthesis", SearchQueryParseError)
+		}
+		p.pos++
+		return node, nil
+	case '"':
+		phrase, err := p.readQuoted()
+		if err != nil {
+			return nil, err
+		}
+		return p.newTerm(phrase, true), nil
+	case '[':
+		return p.parseProperty()
+	}
+
+	word := p.readWord()
+	if name, _, found := strings.Cut(word, ":"); found && p.scope == scopeDefault {
+		if scope, ok := queryOperators[strings.ToLower(name)]; ok {
+			return p.parseOperator(scope, word[len(name)+1:])
+		}
+	}
+	return p.newTerm(word, false), nil
+}
+
+// parseOperator parses the value of an operator, which is a word, a "phrase" or a (group).
+func (p *queryParser) parseOperator(scope queryScope, value string) (queryNode, error) {
+	p.scope = scope
+	defer func() { p.scope = scopeDefault }()
+
+	var child queryNode
+	var err error
+	switch {
+	case value != "":
+		child = p.newTerm(value, false)
+	case p.peek() == '(' || p.peek() == '"':
+		child, err = p.parsePrimary()
+	default:
+		return nil, fmt.Errorf("%s: missing value for operator", SearchQueryParseError)
+	}
+	if err != nil || child == nil {
+		return nil, err
+	}
+	return &scopeNode{scope: scope, child: child}, nil
+}
+
+func (p *queryParser) parseProperty() (queryNode, error) {
+	end := p.pos
+	for end < len(p.input) && p.input[end] != ']' {
+		end++
+	}
+	if end == len(p.input) {
+		return nil, fmt.Errorf("%s: missing closing bracket", SearchQueryParseError)
+	}
+	content := string(p.input[p.pos+1 : end])
+	p.pos = end + 1
+
+	key, value, _ := strings.Cut(content, ":")
+	value = strings.Trim(strings.TrimSpace(value), `"`)
+	return &propertyNode{key: strings.TrimSpace(key), value: value}, nil
+}
+
+func (p *queryParser) readQuoted() (string, error) {
+	p.pos++
+	start := p.pos
+	for !p.eof() && p.peek() != '"' {
+		p.pos++
+	}
+	if p.eof() {
+		return "", fmt.Errorf("%s: missing closing quote", SearchQueryParseError)
+	}
+	value := string(p.input[start:p.pos])
+	p.pos++
+	return value, nil
+}
+
+// readWord reads until whitespace or a closing parenthesis. A quoted value directly
+// after an operator, like path:"my folder", is kept for the operator to read.
+func (p *queryParser) readWord() string {
+	start := p.pos
+	for !p.eof() {
+		r := p.peek()
+		if unicode.IsSpace(r) || r == ')' {
+			break
+		}
+		if (r == '"' || r == '(') && p.pos > start && p.input[p.pos-1] == ':' {
+			break
+		}
+		p.pos++
+	}
+	return string(p.input[start:p.pos])
+}
+
+func (p *queryParser) newTerm(raw string, quoted bool) queryNode {
+	var tokens, caseTokens []string
+	for _, token := range tokenize(raw) {
+		tokens = append(tokens, token.Text)
+		caseTokens = append(caseTokens, raw[token.Start:token.End])
+	}
+	if len(tokens) == 0 && p.scope != scopePath && p.scope != scopeFile && p.scope != scopeTag {
+		return nil
+	}
+	term := &termNode{
+		raw:        raw,
+		term:       queryTerm{tokens: tokens, phrase: quoted || len(tokens) > 1},
+		caseTokens: caseTokens,
+		scope:      p.scope,
+		negated:    p.depth%2 == 1,
+	}
+	p.terms = append(p.terms, term)
+	return term
+}
+
+// tokensFor returns the tokens of the term in the case the search compares them in.
+func (t *termNode) tokensFor(opts SearchOptions) []string {
+	if opts.CaseSensitive {
+		return t.caseTokens
+	}
+	return t.term.tokens
+}
+
+func (t *termNode) matchesContent() bool {
+	return t.scope == scopeDefault || t.scope == scopeContent || t.scope == scopeLine || t.scope == scopeSection
+}
+
+// queryNodeCost estimates how expensive a node is to evaluate: conditions answered by
+// the index cost nothing, conditions that read the note content cost more.
+func queryNodeCost(node queryNode) int {
+	switch n := node.(type) {
+	case *andNode:
+		return maxQueryNodeCost(n.children)
+	case *orNode:
+		return maxQueryNodeCost(n.children)
+	case *notNode:
+		return queryNodeCost(n.child)
+	case *termNode:
+		if n.term.phrase {
+			return 1
+		}
+	case *scopeNode:
+		if n.scope == scopeContent || n.scope == scopeLine || n.scope == scopeSection {
+			return 2
+		}
+	}
+	return 0
+}
+
+func maxQueryNodeCost(nodes []queryNode) int {
+	cost := 0
+	for _, node := range nodes {
+		if c := queryNodeCost(node); c > cost {
+			cost = c
+		}
+	}
+	return cost
+}
+
+// searchCandidate gives query evaluation access to a note, reading its content only when needed.
+type searchCandidate struct {
+	sidx    *SearchIndex
+	relPath string
+	entry   *IndexEntry
+	opts    SearchOptions
+	content *string
+}
+
+func (c *searchCandidate) text() string {
+	if c.content == nil {
+		content := ""
+		if c.entry.Size <= maxFileSizeBytes {
+			if data, err := os.ReadFile(filepath.Join(c.sidx.VaultPath, c.relPath)); err == nil {
+				content = string(data)
+			}
+		}
+		c.content = &content
+	}
+	return *c.content
+}
+
+// sections splits the note content at every heading.
+func (c *searchCandidate) sections() []string {
+	var sections []string
+	var current []string
+	for _, line := range strings.Split(c.text(), "\n") {
+		if headingRegex.MatchString(strings.TrimRight(line, "\r")) && len(current) > 0 {
+			sections = append(sections, strings.Join(current, "\n"))
+			current = nil
+		}
+		current = append(current, line)
+	}
+	return append(sections, strings.Join(current, "\n"))
+}
+
+// matches evaluates a query node against the whole note.
+func (c *searchCandidate) matches(node queryNode) bool {
+	switch n := node.(type) {
+	case *andNode:
+		for _, child := range n.children {
+			if !c.matches(child) {
+				return false
+			}
+		}
+		return true
+	case *orNode:
+		for _, child := range n.children {
+			if c.matches(child) {
+				return true
+			}
+		}
+		return false
+	case *notNode:
+		return !c.matches(n.child)
+	case *termNode:
+		return n.docs[c.relPath]
+	case *propertyNode:
+		return matchesProperty(c.entry.Frontmatter, n.key, n.value)
+	case *scopeNode:
+		return c.matchesScope(n)
+	}
+	return false
+}
+
+func (c *searchCandidate) matchesScope(n *scopeNode) bool {
+	switch n.scope {
+	case scopePath:
+		return c.matchesText(n.child, c.scopedText(normalizePathSeparators(c.relPath), true))
+	case scopeFile:
+		return c.matchesText(n.child, c.scopedText(filepath.Base(c.relPath), true))
+	case scopeTag:
+		return c.matchesTags(n.child)
+	case scopeContent:
+		return c.matchesText(n.child, c.scopedText(c.text(), false))
+	case scopeLine:
+		for _, line := range strings.Split(c.text(), "\n") {
+			if c.matchesText(n.child, c.scopedText(line, false)) {
+				return true
+			}
+		}
+	case scopeSection:
+		for _, section := range c.sections() {
+			if c.matchesText(n.child, c.scopedText(section, false)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// scopedText is the text an operator restricts its terms to, tokenized on first use.
+// Paths and file names are matched as plain substrings, like Obsidian does.
+type scopedText struct {
+	raw       string
+	substring bool
+	opts      SearchOptions
+	tokens    []searchToken
+	tokenized bool
+}
+
+func (c *searchCandidate) scopedText(raw string, substring bool) *scopedText {
+	return &scopedText{raw: raw, substring: substring, opts: c.opts}
+}
+
+func (s *scopedText) contains(term *termNode) bool {
+	if s.substring {
+		if s.opts.CaseSensitive {
+			return strings.Contains(s.raw, term.raw)
+		}
+		return strings.Contains(strings.ToLower(s.raw), strings.ToLower(term.raw))
+	}
+	if !s.tokenized {
+		s.tokens = tokenize(s.raw)
+		s.tokenized = true
+	}
+	_, _, ok := findTerm(s.raw, s.tokens, term.tokensFor(s.opts), term.term.phrase, s.opts)
+	return ok
+}
+
+// matchesText evaluates a node against the text selected by an operator.
+func (c *searchCandidate) matchesText(node queryNode, text *scopedText) bool {
+	switch n := node.(type) {
+	case *andNode:
+		for _, child := range n.children {
+			if !c.matchesText(child, text) {
+				return false
+			}
+		}
+		return true
+	case *orNode:
+		for _, child := range n.children {
+			if c.matchesText(child, text) {
+				return true
+			}
+		}
+		return false
+	case *notNode:
+		return !c.matchesText(n.child, text)
+	case *termNode:
+		return text.contains(n)
+	}
+	return c.matches(node)
+}
+
+// matchesTags evaluates tag:#name, which also matches nested tags like #name/child.
+func (c *searchCandidate) matchesTags(node queryNode) bool {
+	switch n := node.(type) {
+	case *andNode:
+		for _, child := range n.children {
+			if !c.matchesTags(child) {
+				return false
+			}
+		}
+		return true
+	case *orNode:
+		for _, child := range n.children {
+			if c.matchesTags(child) {
+				return true
+			}
+		}
+		return false
+	case *notNode:
+		return !c.matchesTags(n.child)
+	case *termNode:
+		wanted := strings.ToLower(strings.TrimPrefix(n.raw, "#"))
+		for _, tag := range c.entry.Tags {
+			tag = strings.ToLower(tag)
+			if tag == wanted || strings.HasPrefix(tag, wanted+"/") {
+				return true
+			}
+		}
+		return false
+	}
+	return c.matches(node)
+}
+
+// matchesProperty checks a frontmatter key exists and, when a value is given,
+// that the value (or any list item) contains it, ignoring case.
+func matchesProperty(fm map[string]interface{}, key, value string) bool {
+	var found interface{}
+	exists := false
+	for k, v := range fm {
+		if strings.EqualFold(k, key) {
+			found, exists = v, true
+			break
+		}
+	}
+	if !exists {
+		return false
+	}
+	if value == "" {
+		return true
+	}
+
+	value = strings.ToLower(value)
+	if items, ok := found.([]interface{}); ok {
+		for _, item := range items {
+			if strings.Contains(strings.ToLower(fmt.Sprintf("%v", item)), value) {
+				return true
+			}
+		}
+		return false
+	}
+	return strings.Contains(strings.ToLower(fmt.Sprintf("%v", found)), value)
+}
@@ -0,0 +1,268 @@
+package obsidian_test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/stretchr/testify/assert"
+)
+
+func searchPaths(matches []obsidian.NoteMatch) []string {
+	var paths []string
+	seen := make(map[string]bool)
+	for _, match := range matches {
+		if !seen[match.FilePath] {
+			seen[match.FilePath] = true
+			paths = append(paths, match.FilePath)
+		}
+	}
+	return paths
+}
+
+func TestSearchNotesWithSnippets_Ranking(t *testing.T) {
+	vaultDir := t.TempDir()
+	writeVaultFiles(t, vaultDir, map[string]string{
+		"Kubernetes.md":    "# Cluster setup\nNotes about deploying things.",
+		"deploy-log.md":    "We mentioned kubernetes once in a long note about many other topics and more words.",
+		"ops/runbook.md":   "## Kubernetes upgrades\nkubernetes kubernetes rollout checklist",
+		"groceries.md":     "milk, eggs, bread",
+		"release.md":       "---\nstatus: done\n---\nThe release checklist for ios and android",
+		"checklist.md":     "---\nstatus: draft\n---\nA checklist without the release word order: ios checklist release",
+		"international.md": "Café notes — Привет мир",
+	})
+	note := obsidian.Note{}
+
+	t.Run("Title and heading matches outrank a passing mention", func(t *testing.T) {
+		// Act
+		matches, err := note.SearchNotesWithSnippets(vaultDir, "kubernetes", obsidian.SearchOptions{})
+
+		// Assert
+		assert.NoError(t, err)
+		paths := searchPaths(matches)
+		assert.Len(t, paths, 3)
+		assert.ElementsMatch(t, []string{"Kubernetes.md", filepath.Join("ops", "runbook.md")}, paths[:2])
+		assert.Equal(t, "deploy-log.md", paths[2])
+		assert.Greater(t, matches[0].Score, matches[len(matches)-1].Score)
+	})
+
+	t.Run("Every word of a multi-word query must match", func(t *testing.T) {
+		// Act
+		matches, err := note.SearchNotesWithSnippets(vaultDir, "checklist ios", obsidian.SearchOptions{})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []string{"release.md", "checklist.md"}, searchPaths(matches))
+	})
+
+	t.Run("Quoted phrases must appear in order", func(t *testing.T) {
+		// Act
+		matches, err := note.SearchNotesWithSnippets(vaultDir, `"release checklist"`, obsidian.SearchOptions{})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"release.md"}, searchPaths(matches))
+	})
+
+	t.Run("Words match as substrings of indexed terms", func(t *testing.T) {
+		// Act
+		matches, err := note.SearchNotesWithSnippets(vaultDir, "grocer", obsidian.SearchOptions{})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"groceries.md"}, searchPaths(matches))
+		assert.Equal(t, 0, matches[0].LineNumber)
+	})
+
+	t.Run("Unicode words are tokenized", func(t *testing.T) {
+		// Act
+		matches, err := note.SearchNotesWithSnippets(vaultDir, "привет café", obsidian.SearchOptions{})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"international.md"}, searchPaths(matches))
+		assert.Equal(t, 1, matches[0].LineNumber)
+	})
+
+	t.Run("Limit returns the top notes only", func(t *testing.T) {
+		// Act
+		matches, err := note.SearchNotesWithSnippets(vaultDir, "kubernetes", obsidian.SearchOptions{Limit: 2})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NotContains(t, searchPaths(matches), "deploy-log.md")
+		assert.Len(t, searchPaths(matches), 2)
+	})
+
+	t.Run("Metadata filters use the indexed frontmatter", func(t *testing.T) {
+		// Act
+		matches, err := note.SearchNotesWithSnippets(vaultDir, "checklist", obsidian.SearchOptions{
+			MetadataFilters: map[string]string{"status": "draft"},
+		})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"checklist.md"}, searchPaths(matches))
+	})
+
+	t.Run("Long lines are cut around the match without splitting characters", func(t *testing.T) {
+		// Arrange
+		longVault := t.TempDir()
+		line := strings.Repeat("ééééé ", 20) + "needle " + strings.Repeat("ààààà ", 20)
+		writeVaultFiles(t, longVault, map[string]string{"long.md": line})
+
+		// Act
+		matches, err := note.SearchNotesWithSnippets(longVault, "needle", obsidian.SearchOptions{})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, matches, 1)
+		assert.True(t, strings.HasPrefix(matches[0].MatchLine, "..."))
+		assert.True(t, strings.HasSuffix(matches[0].MatchLine, "..."))
+		assert.Contains(t, matches[0].MatchLine, "needle")
+		assert.Equal(t, strings.ToValidUTF8(matches[0].MatchLine, ""), matches[0].MatchLine)
+	})
+}
+
+func TestSearchNotesWithSnippets_Operators(t *testing.T) {
+	vaultDir := t.TempDir()
+	writeVaultFiles(t, vaultDir, map[string]string{
+		"projects/alpha.md": "---\nstatus: active\ntags: [work]\n---\n# Plan\nmeeting notes for alpha\n# Budget\ncosts are high",
+		"projects/beta.md":  "---\nstatus: done\n---\nmeeting about budget #work/archive",
+		"journal/today.md":  "A meeting happened.\nbudget talk later #personal",
+		"ideas.md":          "meeting budget ideas on one line",
+	})
+	note := obsidian.Note{}
+
+	tests := []struct {
+		name     string
+		query    string
+		expected []string
+	}{
+		{"path restricts to a folder", "meeting path:projects", []string{filepath.Join("projects", "alpha.md"), filepath.Join("projects", "beta.md")}},
+		{"file matches the file name", "file:ide", []string{"ideas.md"}},
+		{"tag matches frontmatter, inline and nested tags", "tag:#work", []string{filepath.Join("projects", "alpha.md"), filepath.Join("projects", "beta.md")}},
+		{"negation excludes notes", "meeting -path:projects", []string{filepath.Join("journal", "today.md"), "ideas.md"}},
+		{"OR matches either side", "file:today OR file:ideas", []string{filepath.Join("journal", "today.md"), "ideas.md"}},
+		{"groups combine with AND", "(file:alpha OR file:beta) budget", []string{filepath.Join("projects", "alpha.md"), filepath.Join("projects", "beta.md")}},
+		{"line requires every word on one line", "line:(meeting budget)", []string{filepath.Join("projects", "beta.md"), "ideas.md"}},
+		{"section requires every word between headings", "section:(meeting costs)", nil},
+		{"section matches inside one heading", "section:(budget costs)", []string{filepath.Join("projects", "alpha.md")}},
+		{"property matches a frontmatter value", "[status:active]", []string{filepath.Join("projects", "alpha.md")}},
+		{"property without value checks the key exists", "meeting [status]", []string{filepath.Join("projects", "alpha.md"), filepath.Join("projects", "beta.md")}},
+		{"quoted operator values", `path:"journal" "budget talk"`, []string{filepath.Join("journal", "today.md")}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Act
+			matches, err := note.SearchNotesWithSnippets(vaultDir, test.query, obsidian.SearchOptions{})
+
+			// Assert
+			assert.NoError(t, err)
+			assert.ElementsMatch(t, test.expected, searchPaths(matches))
+		})
+	}
+
+	t.Run("Invalid queries return an error", func(t *testing.T) {
+		for _, query := range []string{"(meeting", "meeting)", `"meeting`, "[status", "path:"} {
+			// Act
+			_, err := note.SearchNotesWithSnippets(vaultDir, query, obsidian.SearchOptions{})
+
+			// Assert
+			assert.ErrorContains(t, err, obsidian.SearchQueryParseError, query)
+		}
+	})
+}
+
+func TestSearchNotesWithSnippets_Modes(t *testing.T) {
+	vaultDir := t.TempDir()
+	writeVaultFiles(t, vaultDir, map[string]string{
+		"tickets.md": "Fixed OPS-1234 on 2024-03-05\nsee also ops-99",
+		"art.md":     "Modern art gallery",
+		"start.md":   "How to start the project",
+		"Go.md":      "go is lowercase here",
+	})
+	note := obsidian.Note{}
+
+	t.Run("Regex matches patterns and centres the snippet on the match", func(t *testing.T) {
+		// Act
+		matches, err := note.SearchNotesWithSnippets(vaultDir, `\d{4}-\d{2}-\d{2}`, obsidian.SearchOptions{Regex: true})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, matches, 1)
+		assert.Equal(t, "tickets.md", matches[0].FilePath)
+		assert.Equal(t, 1, matches[0].LineNumber)
+	})
+
+	t.Run("Regex ignores case unless case-sensitive", func(t *testing.T) {
+		// Act
+		insensitive, err := note.SearchNotesWithSnippets(vaultDir, `[A-Z]+-\d+`, obsidian.SearchOptions{Regex: true})
+		assert.NoError(t, err)
+		sensitive, err := note.SearchNotesWithSnippets(vaultDir, `[A-Z]+-\d+`, obsidian.SearchOptions{Regex: true, CaseSensitive: true})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, insensitive, 2)
+		assert.Len(t, sensitive, 1)
+		assert.Equal(t, "Fixed OPS-1234 on 2024-03-05", sensitive[0].MatchLine)
+	})
+
+	t.Run("Invalid regex returns an error", func(t *testing.T) {
+		// Act
+		_, err := note.SearchNotesWithSnippets(vaultDir, `(unclosed`, obsidian.SearchOptions{Regex: true})
+
+		// Assert
+		assert.ErrorContains(t, err, obsidian.SearchRegexError)
+	})
+
+	t.Run("Anchored regex matches indented lines with CRLF endings", func(t *testing.T) {
+		// Arrange
+		crlfVault := t.TempDir()
+		writeVaultFiles(t, crlfVault, map[string]string{
+			"windows.md": "# Tasks\r\n  - ship it\r\nnot - ship it now\r\n",
+		})
+
+		// Act
+		matches, err := note.SearchNotesWithSnippets(crlfVault, `^- ship it$`, obsidian.SearchOptions{Regex: true})
+
+		// Assert
+		assert.NoError(t, err)
+		if assert.Len(t, matches, 1) {
+			assert.Equal(t, "windows.md", matches[0].FilePath)
+			assert.Equal(t, 2, matches[0].LineNumber)
+			assert.Equal(t, "- ship it", matches[0].MatchLine)
+		}
+	})
+
+	t.Run("Word mode only matches whole words", func(t *testing.T) {
+		// Act
+		substring, err := note.SearchNotesWithSnippets(vaultDir, "art", obsidian.SearchOptions{})
+		assert.NoError(t, err)
+		word, err := note.SearchNotesWithSnippets(vaultDir, "art", obsidian.SearchOptions{Word: true})
+		assert.NoError(t, err)
+		regexWord, err := note.SearchNotesWithSnippets(vaultDir, "art", obsidian.SearchOptions{Regex: true, Word: true})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []string{"art.md", "start.md"}, searchPaths(substring))
+		assert.Equal(t, []string{"art.md"}, searchPaths(word))
+		assert.Equal(t, []string{"art.md"}, searchPaths(regexWord))
+	})
+
+	t.Run("Case-sensitive queries match the exact case", func(t *testing.T) {
+		// Act
+		matches, err := note.SearchNotesWithSnippets(vaultDir, "OPS", obsidian.SearchOptions{CaseSensitive: true})
+		assert.NoError(t, err)
+		lines, err := note.SearchNotesWithSnippets(vaultDir, "line:(ops see)", obsidian.SearchOptions{CaseSensitive: true})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, matches, 1)
+		assert.Equal(t, 1, matches[0].LineNumber)
+		assert.Equal(t, []string{"tickets.md"}, searchPaths(lines))
+		assert.Equal(t, 2, lines[0].LineNumber)
+	})
+}
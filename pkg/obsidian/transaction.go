@@ -0,0 +1,318 @@
+package obsidian
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Transaction groups the file changes of a command so they are applied together
+// or not at all. New note content is staged in temporary files next to the notes
+// and synced to disk; Commit renames them into place. Renames happen right away
+// and removals move the file aside, so later reads see the new layout; Rollback
+// moves everything back and restores the notes already replaced.
+type Transaction struct {
+	writes   []*stagedWrite
+	moves    []movedFile
+	dirs     []string
+	onCommit []func()
+	journal  *Journal
+	done     bool
+}
+
+// movedFile is a rename made by the transaction. Removed files are moved aside
+// until the transaction is committed.
+type movedFile struct {
+	from    string
+	to      string
+	removed bool
+}
+
+// stagedWrite is new content for a file, waiting in a temporary file.
+type stagedWrite struct {
+	path      string
+	tempPath  string
+	original  []byte
+	existed   bool
+	hash      string
+	mode      fs.FileMode
+	committed bool
+}
+
+func NewTransaction() *Transaction {
+	return &Transaction{}
+}
+
+// staged returns the pending write of the file at path, if any.
+func (tx *Transaction) staged(path string) *stagedWrite {
+	for _, write := range tx.writes {
+		if write.path == path {
+			return write
+		}
+	}
+	return nil
+}
+
+// Read returns the content of the file at path with the staged writes applied.
+func (tx *Transaction) Read(path string) ([]byte, error) {
+	if write := tx.staged(path); write != nil {
+		return os.ReadFile(write.tempPath)
+	}
+	return os.ReadFile(path)
+}
+
+// Write stages new content for the file at path. An existing file keeps its mode
+// and must be writable; a new file is created with perm.
+func (tx *Transaction) Write(path string, content []byte, perm fs.FileMode) error {
+	write := tx.staged(path)
+	if write == nil {
+		write = &stagedWrite{path: path, mode: perm}
+		if info, err := os.Stat(path); err == nil {
+			// Replacing the file would ignore its permissions, so check them first
+			f, err := os.OpenFile(path, os.O_WRONLY, 0)
+			if err != nil {
+				return newWriteError(VaultWriteError, path, err)
+			}
+			f.Close()
+			original, err := os.ReadFile(path)
+			if err != nil {
+				return ErrVaultRead
+			}
+			write.original, write.existed, write.mode = original, true, info.Mode().Perm()
+		}
+		tx.writes = append(tx.writes, write)
+	}
+
+	tempPath, err := writeTempFile(path, content, write.mode)
+	if err != nil {
+		return err
+	}
+	if write.tempPath != "" {
+		os.Remove(write.tempPath)
+	}
+	write.tempPath = tempPath
+	write.hash = contentHash(content)
+	return nil
+}
+
+// Rename moves a file or folder right away. Staged writes inside it move along.
+func (tx *Transaction) Rename(oldPath, newPath string) error {
+	if err := os.Rename(oldPath, newPath); err != nil {
+		return err
+	}
+	tx.moves = append(tx.moves, movedFile{from: oldPath, to: newPath})
+	for _, write := range tx.writes {
+		write.path = movedPath(write.path, oldPath, newPath)
+		write.tempPath = movedPath(write.tempPath, oldPath, newPath)
+	}
+	return nil
+}
+
+// Remove moves a file aside; it is deleted when the transaction is committed. A
+// write staged for the file is dropped, which is all there is to remove when the
+// file only exists as a staged write.
+func (tx *Transaction) Remove(path string) error {
+	if write := tx.staged(path); write != nil && !fileExists(path) {
+		tx.dropWrite(write)
+		return nil
+	}
+
+	aside := filepath.Join(filepath.Dir(path), "."+filepath.Base(path)+".removed")
+	for i := 1; fileExists(aside); i++ {
+		aside = filepath.Join(filepath.Dir(path), fmt.Sprintf(".%s.removed-%d", filepath.Base(path), i))
+	}
+	if err := os.Rename(path, aside); err != nil {
+		return err
+	}
+	tx.moves = append(tx.moves, movedFile{from: path, to: aside, removed: true})
+	if write := tx.staged(path); write != nil {
+		tx.dropWrite(write)
+	}
+	return nil
+}
+
+// dropWrite discards a staged write and its temporary file.
+func (tx *Transaction) dropWrite(write *stagedWrite) {
+	os.Remove(write.tempPath)
+	for i := range tx.writes {
+		if tx.writes[i] == write {
+			tx.writes = append(tx.writes[:i], tx.writes[i+1:]...)
+			return
+		}
+	}
+}
+
+// MkdirAll creates a folder and any missing parents, which Rollback removes again.
+func (tx *Transaction) MkdirAll(dir string) error {
+	var missing []string
+	for d := dir; ; d = filepath.Dir(d) {
+		if _, err := os.Stat(d); err == nil || filepath.Dir(d) == d {
+			break
+		}
+		missing = append(missing, d)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return newWriteError(VaultWriteError, dir, err)
+	}
+	for i := len(missing) - 1; i >= 0; i-- {
+		tx.dirs = append(tx.dirs, missing[i])
+	}
+	return nil
+}
+
+// OnCommit registers a function to run once the changes have been applied.
+func (tx *Transaction) OnCommit(fn func()) {
+	tx.onCommit = append(tx.onCommit, fn)
+}
+
+// Commit renames the staged files into place. If one of them cannot be replaced,
+// every change of the transaction is rolled back.
+func (tx *Transaction) Commit() error {
+	if tx.done {
+		return nil
+	}
+	for _, write := range tx.writes {
+		if err := os.Rename(write.tempPath, write.path); err != nil {
+			return tx.rollback(newWriteError(VaultWriteError, write.path, err))
+		}
+		write.committed = true
+	}
+	tx.done = true
+
+	synced := make(map[string]bool)
+	for _, write := range tx.writes {
+		if dir := filepath.Dir(write.path); !synced[dir] {
+			syncDir(dir)
+			synced[dir] = true
+		}
+	}
+	if tx.journal != nil {
+		// The changes are applied at this point, so a journal failure only costs the undo
+		if err := tx.journal.record(tx); err != nil {
+			fmt.Fprintf(os.Stderr, "Could not record the changes for undo: %v\n", err)
+		}
+	}
+	for _, move := range tx.moves {
+		if move.removed {
+			os.Remove(move.to)
+		}
+	}
+	for _, fn := range tx.onCommit {
+		fn()
+	}
+	return nil
+}
+
+// Rollback undoes the changes of a transaction that has not been committed.
+func (tx *Transaction) Rollback() error {
+	if tx.done {
+		return nil
+	}
+	return tx.rollback(nil)
+}
+
+// rollback undoes every change in reverse order and returns cause, noting any
+// change that could not be undone.
+func (tx *Transaction) rollback(cause error) error {
+	tx.done = true
+	var failed []string
+	for i := len(tx.writes) - 1; i >= 0; i-- {
+		write := tx.writes[i]
+		if !write.committed {
+			os.Remove(write.tempPath)
+			continue
+		}
+		if err := restoreFile(write); err != nil {
+			failed = append(failed, write.path)
+		}
+	}
+	for i := len(tx.moves) - 1; i >= 0; i-- {
+		if err := os.Rename(tx.moves[i].to, tx.moves[i].from); err != nil {
+			failed = append(failed, tx.moves[i].from)
+		}
+	}
+	for i := len(tx.dirs) - 1; i >= 0; i-- {
+		os.Remove(tx.dirs[i])
+	}
+
+	if len(failed) == 0 {
+		return cause
+	}
+	if cause == nil {
+		return fmt.Errorf("Failed to roll back changes to %s", strings.Join(failed, ", "))
+	}
+	return fmt.Errorf("%w (failed to roll back changes to %s)", cause, strings.Join(failed, ", "))
+}
+
+// restoreFile puts back the content a committed write replaced.
+func restoreFile(write *stagedWrite) error {
+	if !write.existed {
+		return os.Remove(write.path)
+	}
+	tempPath, err := writeTempFile(write.path, write.original, write.mode)
+	if err != nil {
+		return err
+	}
+	if err := os.Rename(tempPath, write.path); err != nil {
+		os.Remove(tempPath)
+		return err
+	}
+	return nil
+}
+
+// writeTempFile writes content to a new hidden file in the folder of path, synced
+// to disk, and returns its path. Hidden files are not indexed as notes.
+func writeTempFile(path string, content []byte, mode fs.FileMode) (string, error) {
+	f, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return "", newWriteError(VaultWriteError, path, err)
+	}
+	tempPath := f.Name()
+	fail := func(err error) (string, error) {
+		f.Close()
+		os.Remove(tempPath)
+		return "", newWriteError(VaultWriteError, path, err)
+	}
+
+	if _, err := f.Write(content); err != nil {
+		return fail(err)
+	}
+	if err := f.Sync(); err != nil {
+		return fail(err)
+	}
+	if err := f.Chmod(mode); err != nil {
+		return fail(err)
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(tempPath)
+		return "", newWriteError(VaultWriteError, path, err)
+	}
+	return tempPath, nil
+}
+
+// syncDir flushes a folder so renames into it survive a crash. Not every platform
+// can sync folders, so failures are ignored.
+func syncDir(dir string) {
+	if f, err := os.Open(dir); err == nil {
+		_ = f.Sync()
+		f.Close()
+	}
+}
+
+func fileExists(path string) bool {
+	_, err := os.Lstat(path)
+	return err == nil
+}
+
+// movedPath returns path as it is after oldPath was renamed to newPath.
+func movedPath(path, oldPath, newPath string) string {
+	if path == oldPath {
+		return newPath
+	}
+	if strings.HasPrefix(path, oldPath+string(filepath.Separator)) {
+		return newPath + path[len(oldPath):]
+	}
+	return path
+}
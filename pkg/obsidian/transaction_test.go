@@ -0,0 +1,186 @@
+package obsidian_test
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/stretchr/testify/assert"
+)
+
+func readVaultFile(t *testing.T, path string) string {
+	t.Helper()
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read %s: %v", path, err)
+	}
+	return string(content)
+}
+
+func TestTransaction(t *testing.T) {
+	t.Run("Staged writes are applied on commit", func(t *testing.T) {
+		// Arrange
+		vaultDir := t.TempDir()
+		writeVaultFiles(t, vaultDir, map[string]string{"a.md": "old a"})
+		tx := obsidian.NewTransaction()
+
+		// Act
+		assert.NoError(t, tx.Write(filepath.Join(vaultDir, "a.md"), []byte("new a"), 0644))
+		assert.NoError(t, tx.Write(filepath.Join(vaultDir, "b.md"), []byte("new b"), 0644))
+		staged, _ := tx.Read(filepath.Join(vaultDir, "a.md"))
+		before := readVaultFile(t, filepath.Join(vaultDir, "a.md"))
+		err := tx.Commit()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "new a", string(staged))
+		assert.Equal(t, "old a", before)
+		assert.Equal(t, "new a", readVaultFile(t, filepath.Join(vaultDir, "a.md")))
+		assert.Equal(t, "new b", readVaultFile(t, filepath.Join(vaultDir, "b.md")))
+		entries, _ := os.ReadDir(vaultDir)
+		assert.Len(t, entries, 2, "temporary files are cleaned up")
+	})
+
+	t.Run("Rollback undoes renames, removals and new folders", func(t *testing.T) {
+		// Arrange
+		vaultDir := t.TempDir()
+		writeVaultFiles(t, vaultDir, map[string]string{"a.md": "a", "b.md": "b"})
+		tx := obsidian.NewTransaction()
+		assert.NoError(t, tx.MkdirAll(filepath.Join(vaultDir, "new", "folder")))
+		assert.NoError(t, tx.Rename(filepath.Join(vaultDir, "a.md"), filepath.Join(vaultDir, "new", "folder", "a.md")))
+		assert.NoError(t, tx.Remove(filepath.Join(vaultDir, "b.md")))
+		assert.NoError(t, tx.Write(filepath.Join(vaultDir, "new", "folder", "a.md"), []byte("changed"), 0644))
+
+		// Act
+		err := tx.Rollback()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "a", readVaultFile(t, filepath.Join(vaultDir, "a.md")))
+		assert.Equal(t, "b", readVaultFile(t, filepath.Join(vaultDir, "b.md")))
+		assert.NoDirExists(t, filepath.Join(vaultDir, "new"))
+		entries, _ := os.ReadDir(vaultDir)
+		assert.Len(t, entries, 2)
+	})
+
+	t.Run("Removed files are deleted on commit", func(t *testing.T) {
+		// Arrange
+		vaultDir := t.TempDir()
+		writeVaultFiles(t, vaultDir, map[string]string{"a.md": "a"})
+		tx := obsidian.NewTransaction()
+		assert.NoError(t, tx.Remove(filepath.Join(vaultDir, "a.md")))
+
+		// Act
+		err := tx.Commit()
+
+		// Assert
+		assert.NoError(t, err)
+		entries, _ := os.ReadDir(vaultDir)
+		assert.Empty(t, entries)
+	})
+
+	t.Run("A failed commit restores the files already replaced", func(t *testing.T) {
+		// Arrange
+		vaultDir := t.TempDir()
+		writeVaultFiles(t, vaultDir, map[string]string{"a.md": "old a", "locked/b.md": "old b"})
+		tx := obsidian.NewTransaction()
+		assert.NoError(t, tx.Write(filepath.Join(vaultDir, "a.md"), []byte("new a"), 0644))
+		assert.NoError(t, tx.Write(filepath.Join(vaultDir, "locked", "b.md"), []byte("new b"), 0644))
+		assert.NoError(t, os.Chmod(filepath.Join(vaultDir, "locked"), 0555))
+		defer os.Chmod(filepath.Join(vaultDir, "locked"), 0755)
+
+		// Act
+		err := tx.Commit()
+
+		// Assert
+		assert.ErrorIs(t, err, obsidian.ErrWrite)
+		assert.Equal(t, "old a", readVaultFile(t, filepath.Join(vaultDir, "a.md")))
+		assert.Equal(t, "old b", readVaultFile(t, filepath.Join(vaultDir, "locked", "b.md")))
+	})
+
+	t.Run("Read-only files are not replaced", func(t *testing.T) {
+		// Arrange
+		vaultDir := t.TempDir()
+		path := filepath.Join(vaultDir, "a.md")
+		assert.NoError(t, os.WriteFile(path, []byte("a"), 0444))
+		tx := obsidian.NewTransaction()
+
+		// Act
+		err := tx.Write(path, []byte("new a"), 0644)
+
+		// Assert
+		assert.ErrorIs(t, err, fs.ErrPermission)
+	})
+}
+
+func TestNote_Transact(t *testing.T) {
+	t.Run("A note move is undone when the links cannot be updated", func(t *testing.T) {
+		// Arrange
+		vaultDir := t.TempDir()
+		writeVaultFiles(t, vaultDir, map[string]string{
+			"old.md":   "content",
+			"a.md":     "See [[old]]",
+			"sub/b.md": "See [[old]]",
+		})
+		assert.NoError(t, os.Chmod(filepath.Join(vaultDir, "sub", "b.md"), 0444))
+		noteManager := obsidian.Note{}
+
+		// Act
+		err := noteManager.Transact(func() error {
+			if err := noteManager.Move(filepath.Join(vaultDir, "old"), filepath.Join(vaultDir, "new")); err != nil {
+				return err
+			}
+			return noteManager.UpdateLinks(vaultDir, "old", "new")
+		})
+
+		// Assert
+		assert.ErrorIs(t, err, obsidian.ErrWrite)
+		assert.FileExists(t, filepath.Join(vaultDir, "old.md"))
+		assert.NoFileExists(t, filepath.Join(vaultDir, "new.md"))
+		assert.Equal(t, "See [[old]]", readVaultFile(t, filepath.Join(vaultDir, "a.md")))
+		assert.Equal(t, "See [[old]]", readVaultFile(t, filepath.Join(vaultDir, "sub", "b.md")))
+	})
+
+	t.Run("Changes are applied when fn succeeds", func(t *testing.T) {
+		// Arrange
+		vaultDir := t.TempDir()
+		writeVaultFiles(t, vaultDir, map[string]string{"old.md": "content", "a.md": "See [[old]]"})
+		noteManager := obsidian.Note{}
+
+		// Act
+		err := noteManager.Transact(func() error {
+			if err := noteManager.Move(filepath.Join(vaultDir, "old"), filepath.Join(vaultDir, "new")); err != nil {
+				return err
+			}
+			return noteManager.UpdateLinks(vaultDir, "old", "new")
+		})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.FileExists(t, filepath.Join(vaultDir, "new.md"))
+		assert.Equal(t, "See [[new]]", readVaultFile(t, filepath.Join(vaultDir, "a.md")))
+	})
+
+	t.Run("Errors from fn roll back its changes", func(t *testing.T) {
+		// Arrange
+		vaultDir := t.TempDir()
+		writeVaultFiles(t, vaultDir, map[string]string{"a.md": "a"})
+		noteManager := obsidian.Note{}
+		fnErr := errors.New("failed")
+
+		// Act
+		err := noteManager.Transact(func() error {
+			if err := noteManager.Create(filepath.Join(vaultDir, "a.md"), " more", true); err != nil {
+				return err
+			}
+			return fnErr
+		})
+
+		// Assert
+		assert.Equal(t, fnErr, err)
+		assert.Equal(t, "a", readVaultFile(t, filepath.Join(vaultDir, "a.md")))
+	})
+}
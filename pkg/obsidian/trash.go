@@ -0,0 +1,364 @@
+package obsidian
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Values of the "Deleted files" setting of Obsidian, trashOption in
+// .obsidian/app.json.
+const (
+	TrashSystem = "system"
+	TrashLocal  = "local"
+	TrashNone   = "none"
+)
+
+// LocalTrashFolder is the folder of the vault that deleted files are moved to
+// with the local trash option.
+const LocalTrashFolder = ".trash"
+
+const trashInfoDateFormat = "2006-01-02T15:04:05"
+
+// TrashedNote is a deleted file of the vault that can still be restored. Path is
+// where it was in the vault, Location where it is now.
+type TrashedNote struct {
+	Path      string     `json:"path"`
+	Location  string     `json:"location"`
+	Trash     string     `json:"trash"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+
+	// info is the .trashinfo file of a note in the system trash
+	info string
+}
+
+// SystemTrashDir returns the trash folder of the user: the XDG trash on Linux and
+// other Unix systems, ~/.Trash on macOS. It returns an empty string where the
+// system trash is not supported; deleted notes go to the trash of the vault then.
+var SystemTrashDir = func() string {
+	switch runtime.GOOS {
+	case "windows", "plan9":
+		return ""
+	case "darwin":
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, ".Trash")
+		}
+		return ""
+	}
+	if dataHome := os.Getenv("XDG_DATA_HOME"); dataHome != "" {
+		return filepath.Join(dataHome, "Trash")
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		return filepath.Join(home, ".local", "share", "Trash")
+	}
+	return ""
+}
+
+// usesTrashInfo reports whether the system trash follows the XDG trash spec, with
+// the deleted files in files/ and their original location in info/.
+func usesTrashInfo() bool {
+	return runtime.GOOS != "darwin"
+}
+
+// TrashOption returns the "Deleted files" setting of the vault. Obsidian moves
+// deleted files to the system trash unless configured otherwise.
+func TrashOption(vaultPath string) string {
+	content, err := os.ReadFile(filepath.Join(vaultPath, ".obsidian", "app.json"))
+	if err != nil {
+		return TrashSystem
+	}
+	var appConfig struct {
+		TrashOption string `json:"trashOption"`
+	}
+	if err := json.Unmarshal(content, &appConfig); err != nil {
+		return TrashSystem
+	}
+	switch appConfig.TrashOption {
+	case TrashLocal, TrashNone:
+		return appConfig.TrashOption
+	}
+	return TrashSystem
+}
+
+// trashLocation returns where a file of the vault goes when it is deleted, or nil
+// when it is to be removed permanently.
+func trashLocation(vaultPath string, path string) (*TrashedNote, error) {
+	option := TrashOption(vaultPath)
+	if option == TrashNone {
+		return nil, nil
+	}
+	now := time.Now()
+	relPath, err := filepath.Rel(vaultPath, path)
+	if err != nil {
+		return nil, err
+	}
+	trashed := &TrashedNote{Path: normalizePathSeparators(relPath), Trash: TrashSystem, DeletedAt: &now}
+
+	trashDir := SystemTrashDir()
+	switch {
+	case option == TrashLocal || trashDir == "":
+		trashed.Trash = TrashLocal
+		trashed.Location = uniqueTrashPath(filepath.Join(vaultPath, LocalTrashFolder, relPath), fileExists)
+	case usesTrashInfo():
+		infoDir := filepath.Join(trashDir, "info")
+		trashed.Location = uniqueTrashPath(filepath.Join(trashDir, "files", filepath.Base(path)), func(location string) bool {
+			return fileExists(location) || fileExists(filepath.Join(infoDir, filepath.Base(location)+".trashinfo"))
+		})
+		trashed.info = filepath.Join(infoDir, filepath.Base(trashed.Location)+".trashinfo")
+	default:
+		trashed.Location = uniqueTrashPath(filepath.Join(trashDir, filepath.Base(path)), fileExists)
+	}
+	return trashed, nil
+}
+
+// uniqueTrashPath numbers a file name the way Obsidian does, "Note 2.md", until it
+// does not exist yet.
+func uniqueTrashPath(path string, exists func(string) bool) string {
+	ext := filepath.Ext(path)
+	base := strings.TrimSuffix(path, ext)
+	unique := path
+	for i := 2; exists(unique); i++ {
+		unique = fmt.Sprintf("%s %d%s", base, i, ext)
+	}
+	return unique
+}
+
+// trashInfo returns the content of the .trashinfo file of a deleted file.
+func trashInfo(path string, deletedAt time.Time) []byte {
+	escaped := (&url.URL{Path: filepath.ToSlash(path)}).EscapedPath()
+	return []byte(fmt.Sprintf("[Trash Info]\nPath=%s\nDeletionDate=%s\n", escaped, deletedAt.Format(trashInfoDateFormat)))
+}
+
+// parseTrashInfo reads the original path and deletion date from a .trashinfo file.
+func parseTrashInfo(content []byte) (string, *time.Time) {
+	var path string
+	var deletedAt *time.Time
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		key, value, found := strings.Cut(strings.TrimSpace(scanner.Text()), "=")
+		if !found {
+			continue
+		}
+		switch key {
+		case "Path":
+			if unescaped, err := url.PathUnescape(value); err == nil {
+				path = filepath.FromSlash(unescaped)
+			}
+		case "DeletionDate":
+			if date, err := time.ParseInLocation(trashInfoDateFormat, value, time.Local); err == nil {
+				deletedAt = &date
+			}
+		}
+	}
+	return path, deletedAt
+}
+
+// Trash deletes a note according to the "Deleted files" setting of the vault and
+// returns where it was moved to, or an empty string if it was removed for good.
+func (m *Note) Trash(vaultPath string, path string) (string, error) {
+	note := AddMdSuffix(path)
+	if !fileExists(note) {
+		return "", ErrNoteNotFound
+	}
+	absVaultPath, err := filepath.Abs(vaultPath)
+	if err != nil {
+		return "", ErrVaultAccess
+	}
+	trashed, err := trashLocation(absVaultPath, note)
+	if err != nil {
+		return "", err
+	}
+	if trashed == nil {
+		return "", m.Delete(path)
+	}
+
+	err = m.Transact(func() error {
+		if trashed.info != "" {
+			if err := m.tx.MkdirAll(filepath.Dir(trashed.info)); err != nil {
+				return err
+			}
+			if err := m.tx.Write(trashed.info, trashInfo(note, *trashed.DeletedAt), 0600); err != nil {
+				return err
+			}
+		}
+		return moveFile(m.tx, note, trashed.Location)
+	})
+	if err != nil {
+		return "", err
+	}
+	return trashed.Location, nil
+}
+
+// ListTrash returns the deleted files of the vault found in its trash folder and
+// in the system trash, most recently deleted first.
+func (m *Note) ListTrash(vaultPath string) ([]TrashedNote, error) {
+	absVaultPath, err := absoluteVaultPath(vaultPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var trashed []TrashedNote
+	localTrash := filepath.Join(absVaultPath, LocalTrashFolder)
+	_ = filepath.WalkDir(localTrash, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+		relPath, err := filepath.Rel(localTrash, path)
+		if err != nil {
+			return nil
+		}
+		trashed = append(trashed, TrashedNote{Path: normalizePathSeparators(relPath), Location: path, Trash: TrashLocal})
+		return nil
+	})
+
+	if trashDir := SystemTrashDir(); trashDir != "" && usesTrashInfo() {
+		infoDir := filepath.Join(trashDir, "info")
+		infos, _ := os.ReadDir(infoDir)
+		for _, info := range infos {
+			if info.IsDir() || filepath.Ext(info.Name()) != ".trashinfo" {
+				continue
+			}
+			content, err := os.ReadFile(filepath.Join(infoDir, info.Name()))
+			if err != nil {
+				continue
+			}
+			original, deletedAt := parseTrashInfo(content)
+			relPath, err := filepath.Rel(absVaultPath, original)
+			if original == "" || err != nil || strings.HasPrefix(relPath, "..") {
+				continue
+			}
+			location := filepath.Join(trashDir, "files", strings.TrimSuffix(info.Name(), ".trashinfo"))
+			if !fileExists(location) {
+				continue
+			}
+			trashed = append(trashed, TrashedNote{
+				Path:      normalizePathSeparators(relPath),
+				Location:  location,
+				Trash:     TrashSystem,
+				DeletedAt: deletedAt,
+				info:      filepath.Join(infoDir, info.Name()),
+			})
+		}
+	}
+
+	sort.SliceStable(trashed, func(i, j int) bool {
+		a, b := trashed[i].DeletedAt, trashed[j].DeletedAt
+		if a != nil && b != nil && !a.Equal(*b) {
+			return a.After(*b)
+		}
+		if (a == nil) != (b == nil) {
+			return a != nil
+		}
+		return trashed[i].Path < trashed[j].Path
+	})
+	return trashed, nil
+}
+
+// RestoreTrash moves a deleted note back to where it was in the vault. The note is
+// given by its original path or file name; if it was deleted more than once, the
+// most recent copy is restored.
+func (m *Note) RestoreTrash(vaultPath string, noteName string) (TrashedNote, error) {
+	trashed, err := m.ListTrash(vaultPath)
+	if err != nil {
+		return TrashedNote{}, err
+	}
+	note, err := findTrashedNote(trashed, noteName)
+	if err != nil {
+		return TrashedNote{}, err
+	}
+	absVaultPath, err := absoluteVaultPath(vaultPath)
+	if err != nil {
+		return TrashedNote{}, err
+	}
+	destination := filepath.Join(absVaultPath, filepath.FromSlash(note.Path))
+	if fileExists(destination) {
+		return TrashedNote{}, fmt.Errorf("Cannot restore %s, a file with that name already exists", note.Path)
+	}
+
+	err = m.Transact(func() error {
+		if err := m.tx.MkdirAll(filepath.Dir(destination)); err != nil {
+			return err
+		}
+		if err := moveFile(m.tx, note.Location, destination); err != nil {
+			return err
+		}
+		if note.info != "" {
+			if err := m.tx.Remove(note.info); err != nil {
+				return newWriteError(VaultWriteError, note.info, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return TrashedNote{}, err
+	}
+	return note, nil
+}
+
+// findTrashedNote picks the deleted note with the given original path or file
+// name from trashed, which is sorted most recent first.
+func findTrashedNote(trashed []TrashedNote, noteName string) (TrashedNote, error) {
+	name := strings.ToLower(RemoveMdSuffix(normalizePathSeparators(strings.TrimPrefix(noteName, "./"))))
+	var byName []TrashedNote
+	for _, note := range trashed {
+		notePath := strings.ToLower(RemoveMdSuffix(note.Path))
+		if notePath == name {
+			return note, nil
+		}
+		if !strings.Contains(name, "/") && strings.HasSuffix("/"+notePath, "/"+name) {
+			byName = append(byName, note)
+		}
+	}
+
+	var candidates []string
+	seen := make(map[string]bool)
+	for _, note := range byName {
+		if !seen[note.Path] {
+			seen[note.Path] = true
+			candidates = append(candidates, note.Path)
+		}
+	}
+	switch len(candidates) {
+	case 0:
+		return TrashedNote{}, ErrNoteNotFound
+	case 1:
+		return byName[0], nil
+	}
+	return TrashedNote{}, &AmbiguousNoteError{Name: noteName, Candidates: candidates}
+}
+
+// moveFile moves a file within a transaction. Across file systems, where it
+// cannot be renamed, the file is copied and the original removed.
+func moveFile(tx *Transaction, from string, to string) error {
+	if err := tx.MkdirAll(filepath.Dir(to)); err != nil {
+		return err
+	}
+	if err := tx.Rename(from, to); err == nil {
+		return nil
+	}
+
+	info, err := os.Stat(from)
+	if err != nil {
+		return ErrNoteNotFound
+	}
+	content, err := os.ReadFile(from)
+	if err != nil {
+		return ErrVaultRead
+	}
+	if err := tx.Write(to, content, info.Mode().Perm()); err != nil {
+		return err
+	}
+	if err := tx.Remove(from); err != nil {
+		return newWriteError(VaultWriteError, from, err)
+	}
+	return nil
+}
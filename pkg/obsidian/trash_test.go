@@ -0,0 +1,171 @@
+package obsidian_test
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/stretchr/testify/assert"
+)
+
+func setTrashOption(t *testing.T, vaultDir string, option string) {
+	t.Helper()
+	writeVaultFiles(t, vaultDir, map[string]string{".obsidian/app.json": `{"trashOption": "` + option + `"}`})
+}
+
+func TestTrashOption(t *testing.T) {
+	t.Run("Defaults to the system trash", func(t *testing.T) {
+		assert.Equal(t, obsidian.TrashSystem, obsidian.TrashOption(t.TempDir()))
+	})
+
+	t.Run("Reads the setting from app.json", func(t *testing.T) {
+		vaultDir := t.TempDir()
+		setTrashOption(t, vaultDir, "local")
+		assert.Equal(t, obsidian.TrashLocal, obsidian.TrashOption(vaultDir))
+	})
+}
+
+func TestNote_Trash(t *testing.T) {
+	t.Run("Moves notes to the trash folder of the vault", func(t *testing.T) {
+		// Arrange
+		vaultDir := t.TempDir()
+		setTrashOption(t, vaultDir, "local")
+		writeVaultFiles(t, vaultDir, map[string]string{"sub/note.md": "content", ".trash/sub/note.md": "older"})
+		noteManager := obsidian.Note{}
+
+		// Act
+		location, err := noteManager.Trash(vaultDir, filepath.Join(vaultDir, "sub", "note"))
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, filepath.Join(vaultDir, ".trash", "sub", "note 2.md"), location)
+		assert.Equal(t, "content", readVaultFile(t, location))
+		assert.NoFileExists(t, filepath.Join(vaultDir, "sub", "note.md"))
+	})
+
+	t.Run("Deletes notes permanently when the vault is set to", func(t *testing.T) {
+		// Arrange
+		vaultDir := t.TempDir()
+		setTrashOption(t, vaultDir, "none")
+		writeVaultFiles(t, vaultDir, map[string]string{"note.md": "content"})
+		noteManager := obsidian.Note{}
+
+		// Act
+		location, err := noteManager.Trash(vaultDir, filepath.Join(vaultDir, "note"))
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Empty(t, location)
+		assert.NoFileExists(t, filepath.Join(vaultDir, "note.md"))
+	})
+
+	t.Run("Moves notes to the system trash with their original path", func(t *testing.T) {
+		if runtime.GOOS == "darwin" || runtime.GOOS == "windows" {
+			t.Skip("the system trash does not record original paths on this platform")
+		}
+		// Arrange
+		vaultDir := t.TempDir()
+		trashDir := t.TempDir()
+		originalTrashDir := obsidian.SystemTrashDir
+		defer func() { obsidian.SystemTrashDir = originalTrashDir }()
+		obsidian.SystemTrashDir = func() string { return trashDir }
+		writeVaultFiles(t, vaultDir, map[string]string{"my notes/note.md": "content"})
+		noteManager := obsidian.Note{}
+
+		// Act
+		location, err := noteManager.Trash(vaultDir, filepath.Join(vaultDir, "my notes", "note.md"))
+		trashed, listErr := noteManager.ListTrash(vaultDir)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, filepath.Join(trashDir, "files", "note.md"), location)
+		assert.Contains(t, readVaultFile(t, filepath.Join(trashDir, "info", "note.md.trashinfo")), "Path="+filepath.ToSlash(vaultDir)+"/my%20notes/note.md\n")
+		assert.NoError(t, listErr)
+		if assert.Len(t, trashed, 1) {
+			assert.Equal(t, "my notes/note.md", trashed[0].Path)
+			assert.Equal(t, obsidian.TrashSystem, trashed[0].Trash)
+			assert.NotNil(t, trashed[0].DeletedAt)
+		}
+	})
+
+	t.Run("Note does not exist", func(t *testing.T) {
+		// Arrange
+		noteManager := obsidian.Note{}
+
+		// Act
+		_, err := noteManager.Trash(t.TempDir(), "missing")
+
+		// Assert
+		assert.ErrorIs(t, err, obsidian.ErrNoteNotFound)
+	})
+}
+
+func TestNote_RestoreTrash(t *testing.T) {
+	t.Run("Restores a deleted note to where it was", func(t *testing.T) {
+		// Arrange
+		vaultDir := t.TempDir()
+		trashDir := t.TempDir()
+		originalTrashDir := obsidian.SystemTrashDir
+		defer func() { obsidian.SystemTrashDir = originalTrashDir }()
+		obsidian.SystemTrashDir = func() string { return trashDir }
+		writeVaultFiles(t, vaultDir, map[string]string{"sub/note.md": "content"})
+		noteManager := obsidian.Note{}
+		_, err := noteManager.Trash(vaultDir, filepath.Join(vaultDir, "sub", "note"))
+		assert.NoError(t, err)
+
+		// Act
+		restored, err := noteManager.RestoreTrash(vaultDir, "note")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "sub/note.md", restored.Path)
+		assert.Equal(t, "content", readVaultFile(t, filepath.Join(vaultDir, "sub", "note.md")))
+		trashed, _ := noteManager.ListTrash(vaultDir)
+		assert.Empty(t, trashed)
+		entries, _ := os.ReadDir(filepath.Join(trashDir, "info"))
+		assert.Empty(t, entries, "the trash info file is removed")
+	})
+
+	t.Run("Note name matches notes from different folders", func(t *testing.T) {
+		// Arrange
+		vaultDir := t.TempDir()
+		writeVaultFiles(t, vaultDir, map[string]string{".trash/a/note.md": "a", ".trash/b/note.md": "b"})
+		noteManager := obsidian.Note{}
+
+		// Act
+		_, err := noteManager.RestoreTrash(vaultDir, "note")
+
+		// Assert
+		var ambiguous *obsidian.AmbiguousNoteError
+		if assert.ErrorAs(t, err, &ambiguous) {
+			assert.Equal(t, []string{"a/note.md", "b/note.md"}, ambiguous.Candidates)
+		}
+	})
+
+	t.Run("A note with the same path exists again", func(t *testing.T) {
+		// Arrange
+		vaultDir := t.TempDir()
+		writeVaultFiles(t, vaultDir, map[string]string{".trash/note.md": "old", "note.md": "new"})
+		noteManager := obsidian.Note{}
+
+		// Act
+		_, err := noteManager.RestoreTrash(vaultDir, "note.md")
+
+		// Assert
+		assert.Error(t, err)
+		assert.Equal(t, "new", readVaultFile(t, filepath.Join(vaultDir, "note.md")))
+	})
+
+	t.Run("Note is not in the trash", func(t *testing.T) {
+		// Arrange
+		noteManager := obsidian.Note{}
+
+		// Act
+		_, err := noteManager.RestoreTrash(t.TempDir(), "missing")
+
+		// Assert
+		assert.ErrorIs(t, err, obsidian.ErrNoteNotFound)
+	})
+}
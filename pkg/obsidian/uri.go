@@ -7,6 +7,9 @@ import (
 )
 
 type Uri struct {
+	// Err, if set, is returned instead of running a URI. Obsidian cannot open
+	// vaults it does not know, such as vaults given by path.
+	Err error
 }
 
 type UriManager interface {
@@ -31,6 +34,9 @@ func (u *Uri) Construct(baseUri string, params map[string]string) string {
 var Run = open.Run
 
 func (u *Uri) Execute(uri string) error {
+	if u.Err != nil {
+		return u.Err
+	}
 	//fmt.Println("Opening URI: ", uri)
 	err := Run(uri)
 	if err != nil {
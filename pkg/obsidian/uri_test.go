@@ -78,4 +78,17 @@ func TestUriExecute(t *testing.T) {
 		assert.Equal(t, obsidian.ExecuteUriError, err.Error())
 	})
 
+	t.Run("URI manager with an error does not run the URI", func(t *testing.T) {
+		obsidian.Run = func(uri string) error {
+			t.Fatalf("unexpected URI %s", uri)
+			return nil
+		}
+		// Arrange
+		uriManager := obsidian.Uri{Err: obsidian.ErrVaultNotRegistered}
+		// Act
+		err := uriManager.Execute("obsidian://open")
+		// Assert
+		assert.ErrorIs(t, err, obsidian.ErrVaultNotRegistered)
+	})
+
 }
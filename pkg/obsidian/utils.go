@@ -80,7 +80,8 @@ func GenerateBacklinkSearchPatterns(notePath string) []string {
 // This handles:
 // - Simple wikilinks: [[note]], [[note|alias]], [[note#heading]]
 // - Path-based wikilinks: [[folder/note]], [[folder/note|alias]], [[folder/note#heading]]
-// - Markdown links: [text](folder/note.md), [text](./folder/note.md)
+// - Markdown links: [text](folder/note.md), [text](./folder/note.md), [text](folder/note.md#heading)
+// - URL-encoded markdown links: [text](folder/My%20Note.md)
 func GenerateLinkReplacements(oldNotePath, newNotePath string) map[string]string {
 	replacements := make(map[string]string)
 
@@ -120,6 +121,24 @@ func GenerateLinkReplacements(oldNotePath, newNotePath string) map[string]string
 	replacements["](./"+oldMd+")"] = "](./" + newMd + ")"
 	replacements["](./"+oldPathNoExt+")"] = "](./" + newPathNoExt + ")"
 
+	// Markdown links to a heading or block: [text](folder/note.md#heading)
+	replacements["]("+oldMd+"#"] = "](" + newMd + "#"
+	replacements["](./"+oldMd+"#"] = "](./" + newMd + "#"
+
+	// Markdown links with spaces are usually URL-encoded: [text](My%20Note.md)
+	if strings.Contains(oldNormalized, " ") {
+		encode := func(s string) string { return strings.ReplaceAll(s, " ", "%20") }
+		encoded := make(map[string]string)
+		for o, n := range replacements {
+			if strings.HasPrefix(o, "](") {
+				encoded[encode(o)] = encode(n)
+			}
+		}
+		for o, n := range encoded {
+			replacements[o] = n
+		}
+	}
+
 	return replacements
 }
 
@@ -130,6 +149,40 @@ func ReplaceContent(content []byte, replacements map[string]string) []byte {
 	return content
 }
 
+// UnlinkContent replaces the links to a note with their text, as Obsidian displays
+// them: [[note|alias]] becomes "alias", [[note#heading]] "note > heading" and
+// [text](note.md) "text". Like GenerateLinkReplacements it matches wikilinks by
+// basename or path and markdown links by path. Embeds of the note are replaced too;
+// links inside code are left alone.
+func UnlinkContent(content []byte, notePath string) []byte {
+	normalized := normalizePathSeparators(notePath)
+	targets := map[string]bool{
+		RemoveMdSuffix(path.Base(normalized)): true,
+		RemoveMdSuffix(normalized):            true,
+	}
+	linksToNote := func(target string) bool {
+		return targets[RemoveMdSuffix(strings.TrimPrefix(normalizePathSeparators(target), "./"))]
+	}
+
+	return replaceLinks(content, func(link Link, text string) string {
+		if !linksToNote(link.Target) {
+			return text
+		}
+		return linkText(link)
+	})
+}
+
+// linkText returns the text a link is displayed as.
+func linkText(link Link) string {
+	switch {
+	case link.Alias != "":
+		return link.Alias
+	case link.Heading != "":
+		return RemoveMdSuffix(link.Target) + " > " + link.Heading
+	}
+	return RemoveMdSuffix(link.Target)
+}
+
 func ShouldSkipDirectoryOrFile(info os.FileInfo) bool {
 	isDirectory := info.IsDir()
 	isHidden := info.Name()[0] == '.'
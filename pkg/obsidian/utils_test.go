@@ -141,6 +141,15 @@ func TestGenerateLinkReplacements(t *testing.T) {
 		assert.Equal(t, "[[x/y/note]]", replacements["[[a/b/c/note]]"])
 		assert.Equal(t, "](x/y/note.md)", replacements["](a/b/c/note.md)"])
 	})
+
+	t.Run("Markdown links to headings and URL-encoded paths", func(t *testing.T) {
+		replacements := obsidian.GenerateLinkReplacements("folder/old note", "folder/new note")
+
+		assert.Equal(t, "](folder/new note.md#", replacements["](folder/old note.md#"])
+		assert.Equal(t, "](folder/new%20note.md)", replacements["](folder/old%20note.md)"])
+		assert.Equal(t, "](folder/new%20note.md#", replacements["](folder/old%20note.md#"])
+		assert.Equal(t, "](./folder/new%20note)", replacements["](./folder/old%20note)"])
+	})
 }
 
 func TestReplaceContent(t *testing.T) {
@@ -166,6 +175,34 @@ func TestReplaceContent(t *testing.T) {
 
 }
 
+func TestUnlinkContent(t *testing.T) {
+	tests := []struct {
+		testName string
+		content  string
+		notePath string
+		want     string
+	}{
+		{"Wikilink", "See [[note]].", "note", "See note."},
+		{"Wikilink with alias", "See [[note|the note]].", "note", "See the note."},
+		{"Wikilink to a heading", "See [[note#Intro]].", "note", "See note > Intro."},
+		{"Path-based wikilink", "See [[folder/note]] and [[note]].", "folder/note.md", "See folder/note and note."},
+		{"Embed", "![[note]]", "note", "note"},
+		{"Markdown link", "See [this](folder/note.md) and [that](./folder/note.md#Intro).", "folder/note", "See this and that."},
+		{"URL-encoded markdown link", "See [this](My%20Note.md).", "My Note", "See this."},
+		{"Links to other notes are kept", "See [[notes]], [[other|note]] and [x](other.md).", "note", "See [[notes]], [[other|note]] and [x](other.md)."},
+		{"Links inside code are kept", "`[[note]]`\n```\n[[note]]\n```\n", "note", "`[[note]]`\n```\n[[note]]\n```\n"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testName, func(t *testing.T) {
+			// Act
+			got := obsidian.UnlinkContent([]byte(test.content), test.notePath)
+			// Assert
+			assert.Equal(t, test.want, string(got))
+		})
+	}
+}
+
 func TestShouldSkipDirectoryOrFile(t *testing.T) {
 	tests := []struct {
 		testName string
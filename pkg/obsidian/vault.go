@@ -5,20 +5,41 @@ type CliConfig struct {
 	DailyNotePattern string `json:"daily_note_pattern,omitempty"`
 }
 
+// ObsidianVaultConfig is the list of vaults in Obsidian's obsidian.json, keyed by
+// vault ID.
 type ObsidianVaultConfig struct {
-	Vaults map[string]struct {
-		Path string `json:"path"`
-	} `json:"vaults"`
+	Vaults map[string]ObsidianVault `json:"vaults"`
+}
+
+type ObsidianVault struct {
+	Path string `json:"path"`
+	Ts   int64  `json:"ts,omitempty"`
+	Open bool   `json:"open,omitempty"`
+}
+
+// VaultInfo describes a vault known to Obsidian. Its name is the name of its
+// folder; Default marks the default vault of the CLI.
+type VaultInfo struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Path    string `json:"path"`
+	Open    bool   `json:"open"`
+	Default bool   `json:"default"`
 }
 
 type VaultManager interface {
 	DefaultName() (string, error)
 	SetDefaultName(name string) error
 	Path() (string, error)
+	ID() (string, error)
 	DailyNotePattern() (string, error)
 	ResolveDailyNote() (string, error)
 }
 
 type Vault struct {
 	Name string
+	// Dir is the folder of a vault given by path. Such a vault does not have to be
+	// registered in Obsidian: its config is not read, and the vault is named
+	// after the folder.
+	Dir string
 }
@@ -7,6 +7,7 @@ import (
 
 	"github.com/Yakitrak/obsidian-cli/pkg/config"
 	"os"
+	"path/filepath"
 )
 
 var CliConfigPath = config.CliPath
@@ -16,6 +17,14 @@ func (v *Vault) DefaultName() (string, error) {
 	if v.Name != "" {
 		return v.Name, nil
 	}
+	if v.Dir != "" {
+		dir, err := filepath.Abs(v.Dir)
+		if err != nil {
+			return "", err
+		}
+		v.Name = filepath.Base(dir)
+		return v.Name, nil
+	}
 
 	// get cliConfig path
 	_, cliConfigFile, err := CliConfigPath()
@@ -26,7 +35,7 @@ func (v *Vault) DefaultName() (string, error) {
 	// read file
 	content, err := os.ReadFile(cliConfigFile)
 	if err != nil {
-		return "", errors.New(ObsidianCLIConfigReadError)
+		return "", ErrConfigNotFound
 	}
 
 	// unmarshal json
@@ -62,13 +71,12 @@ func (v *Vault) SetDefaultName(name string) error {
 	// create directory
 	err = os.MkdirAll(obsConfigDir, os.ModePerm)
 	if err != nil {
-		return errors.New(ObsidianCLIConfigDirWriteEror)
+		return newWriteError(ObsidianCLIConfigDirWriteEror, obsConfigDir, err)
 	}
 
 	// create and write file
-	err = os.WriteFile(obsConfigFile, jsonContent, 0644)
-	if err != nil {
-		return errors.New(ObsidianCLIConfigWriteError)
+	if err := writeFileAtomic(obsConfigFile, jsonContent, 0644, ObsidianCLIConfigWriteError); err != nil {
+		return err
 	}
 
 	v.Name = name
@@ -97,12 +105,11 @@ func (v *Vault) SetDailyNotePattern(pattern string) error {
 
 	err = os.MkdirAll(obsConfigDir, os.ModePerm)
 	if err != nil {
-		return errors.New(ObsidianCLIConfigDirWriteEror)
+		return newWriteError(ObsidianCLIConfigDirWriteEror, obsConfigDir, err)
 	}
 
-	err = os.WriteFile(obsConfigFile, jsonContent, 0644)
-	if err != nil {
-		return errors.New(ObsidianCLIConfigWriteError)
+	if err := writeFileAtomic(obsConfigFile, jsonContent, 0644, ObsidianCLIConfigWriteError); err != nil {
+		return err
 	}
 
 	return nil
@@ -116,7 +123,7 @@ func (v *Vault) DailyNotePattern() (string, error) {
 
 	content, err := os.ReadFile(cliConfigFile)
 	if err != nil {
-		return "", errors.New(ObsidianCLIDailyPatternNotConfigured)
+		return "", ErrDailyPatternNotConfigured
 	}
 
 	cliConfig := CliConfig{}
@@ -125,7 +132,7 @@ func (v *Vault) DailyNotePattern() (string, error) {
 	}
 
 	if cliConfig.DailyNotePattern == "" {
-		return "", errors.New(ObsidianCLIDailyPatternNotConfigured)
+		return "", ErrDailyPatternNotConfigured
 	}
 
 	return cliConfig.DailyNotePattern, nil
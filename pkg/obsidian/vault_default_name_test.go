@@ -6,6 +6,7 @@ import (
 	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
 	"github.com/stretchr/testify/assert"
 	"os"
+	"path/filepath"
 	"testing"
 )
 
@@ -24,6 +25,16 @@ func TestVaultDefaultName(t *testing.T) {
 			assert.Equal(t, "my-vault", vaultName)
 		})
 
+		t.Run("Get vault name from vault folder", func(t *testing.T) {
+			// Arrange
+			vault := obsidian.Vault{Dir: filepath.Join(t.TempDir(), "notes")}
+			// Act
+			vaultName, err := vault.DefaultName()
+			// Assert
+			assert.Equal(t, nil, err)
+			assert.Equal(t, "notes", vaultName)
+		})
+
 		t.Run("Get vault name from config file", func(t *testing.T) {
 			// Arrange
 			mockCliConfigDir, mockCliConfigFile := mocks.CreateMockCliConfigDirectories(t)
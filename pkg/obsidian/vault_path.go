@@ -3,37 +3,152 @@ package obsidian
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/Yakitrak/obsidian-cli/pkg/config"
 	"os"
-	"strings"
+	"path"
+	"path/filepath"
+	"sort"
 )
 
 var ObsidianConfigFile = config.ObsidianFile
 
+// Path returns the folder of the vault. The vault is looked up in Obsidian's
+// config by ID, by folder name or by its full path.
 func (v *Vault) Path() (string, error) {
-	obsidianConfigFile, err := ObsidianConfigFile()
+	if v.Dir != "" {
+		return vaultDir(v.Dir)
+	}
+
+	vaults, err := obsidianVaults()
+	if err != nil {
+		return "", err
+	}
+	vault, err := findVault(vaults, v.Name)
+	if err != nil {
+		return "", err
+	}
+	return vault.Path, nil
+}
+
+// ID returns the ID Obsidian knows the vault by, which URIs use to pick it even
+// when it was given by path or shares its folder name with another vault.
+func (v *Vault) ID() (string, error) {
+	if v.Dir != "" {
+		return "", ErrVaultNotRegistered
+	}
+	name, err := v.DefaultName()
 	if err != nil {
 		return "", err
 	}
 
+	vaults, err := obsidianVaults()
+	if err != nil {
+		return "", err
+	}
+	vault, err := findVault(vaults, name)
+	if err != nil {
+		return "", err
+	}
+	return vault.ID, nil
+}
+
+// ListVaults returns the vaults known to Obsidian, sorted by name.
+func ListVaults() ([]VaultInfo, error) {
+	vaults, err := obsidianVaults()
+	if err != nil {
+		return nil, err
+	}
+
+	defaultVault := Vault{}
+	if name, err := defaultVault.DefaultName(); err == nil {
+		if vault, err := findVault(vaults, name); err == nil {
+			for i := range vaults {
+				vaults[i].Default = vaults[i].ID == vault.ID
+			}
+		}
+	}
+	return vaults, nil
+}
+
+// obsidianVaults reads the vaults from Obsidian's config, sorted by name and path.
+func obsidianVaults() ([]VaultInfo, error) {
+	obsidianConfigFile, err := ObsidianConfigFile()
+	if err != nil {
+		return nil, err
+	}
+
 	content, err := os.ReadFile(obsidianConfigFile)
 
 	if err != nil {
-		return "", errors.New(ObsidianConfigReadError)
+		return nil, ErrObsidianConfigNotFound
 	}
 
 	vaultsContent := ObsidianVaultConfig{}
 	err = json.Unmarshal(content, &vaultsContent)
 
 	if err != nil {
-		return "", errors.New(ObsidianConfigParseError)
+		return nil, errors.New(ObsidianConfigParseError)
 	}
 
-	for _, element := range vaultsContent.Vaults {
-		if strings.HasSuffix(element.Path, v.Name) {
-			return element.Path, nil
+	vaults := make([]VaultInfo, 0, len(vaultsContent.Vaults))
+	for id, element := range vaultsContent.Vaults {
+		vaults = append(vaults, VaultInfo{
+			ID:   id,
+			Name: vaultFolderName(element.Path),
+			Path: element.Path,
+			Open: element.Open,
+		})
+	}
+	sort.Slice(vaults, func(i, j int) bool {
+		if vaults[i].Name != vaults[j].Name {
+			return vaults[i].Name < vaults[j].Name
+		}
+		return vaults[i].Path < vaults[j].Path
+	})
+	return vaults, nil
+}
+
+// findVault picks the vault with the given ID, folder name or path. A name shared
+// by several vaults is an error listing their paths.
+func findVault(vaults []VaultInfo, name string) (VaultInfo, error) {
+	var matches []VaultInfo
+	for _, vault := range vaults {
+		if vault.ID == name || vault.Path == name {
+			return vault, nil
+		}
+		if vault.Name == name {
+			matches = append(matches, vault)
 		}
 	}
 
-	return "", errors.New(ObsidianConfigVaultNotFoundError)
+	switch len(matches) {
+	case 0:
+		return VaultInfo{}, ErrVaultNotFound
+	case 1:
+		return matches[0], nil
+	}
+	paths := make([]string, len(matches))
+	for i, vault := range matches {
+		paths[i] = vault.Path
+	}
+	return VaultInfo{}, &AmbiguousVaultError{Name: name, Candidates: paths}
+}
+
+// vaultDir returns the absolute path of a vault folder given by path.
+func vaultDir(dir string) (string, error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrVaultFolderNotFound, dir)
+	}
+	if info, err := os.Stat(absDir); err != nil || !info.IsDir() {
+		return "", fmt.Errorf("%w: %s", ErrVaultFolderNotFound, dir)
+	}
+	return absDir, nil
+}
+
+// vaultFolderName returns the last element of a vault path, which may use either
+// path separator depending on the platform Obsidian runs on.
+func vaultFolderName(vaultPath string) string {
+	return path.Base(normalizePathSeparators(vaultPath))
 }
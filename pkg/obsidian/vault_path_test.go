@@ -1,10 +1,12 @@
 package obsidian_test
 
 import (
+	"errors"
 	"github.com/Yakitrak/obsidian-cli/mocks"
 	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
 	"github.com/stretchr/testify/assert"
 	"os"
+	"path/filepath"
 	"testing"
 )
 
@@ -42,6 +44,53 @@ func TestVaultPath(t *testing.T) {
 		assert.Equal(t, "/path/to/vault1", vaultPath)
 	})
 
+	t.Run("Vault names match whole folder names", func(t *testing.T) {
+		// Arrange
+		writeObsidianConfig(t, `{"vaults": {
+			"a1": {"path": "/home/me/work-notes"},
+			"b2": {"path": "/home/me/notes"}
+		}}`)
+		vault := obsidian.Vault{Name: "notes"}
+		// Act
+		vaultPath, err := vault.Path()
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "/home/me/notes", vaultPath)
+	})
+
+	t.Run("Finds a vault by ID or path", func(t *testing.T) {
+		// Arrange
+		writeObsidianConfig(t, `{"vaults": {
+			"a1": {"path": "/home/me/notes"},
+			"b2": {"path": "/home/me/archive/notes"}
+		}}`)
+		// Act
+		byID, idErr := (&obsidian.Vault{Name: "b2"}).Path()
+		byPath, pathErr := (&obsidian.Vault{Name: "/home/me/notes"}).Path()
+		// Assert
+		assert.NoError(t, idErr)
+		assert.Equal(t, "/home/me/archive/notes", byID)
+		assert.NoError(t, pathErr)
+		assert.Equal(t, "/home/me/notes", byPath)
+	})
+
+	t.Run("Vault name shared by several vaults", func(t *testing.T) {
+		// Arrange
+		writeObsidianConfig(t, `{"vaults": {
+			"a1": {"path": "/home/me/notes"},
+			"b2": {"path": "/home/me/archive/notes"}
+		}}`)
+		vault := obsidian.Vault{Name: "notes"}
+		// Act
+		_, err := vault.Path()
+		// Assert
+		var ambiguous *obsidian.AmbiguousVaultError
+		if assert.ErrorAs(t, err, &ambiguous) {
+			assert.Equal(t, []string{"/home/me/archive/notes", "/home/me/notes"}, ambiguous.Candidates)
+		}
+		assert.Equal(t, obsidian.ExitCodeAmbiguousVault, obsidian.ExitCode(err))
+	})
+
 	t.Run("Error in getting obsidian config file ", func(t *testing.T) {
 		// Arrange
 		obsidian.ObsidianConfigFile = func() (string, error) {
@@ -102,4 +151,103 @@ func TestVaultPath(t *testing.T) {
 		// Assert
 		assert.Equal(t, err.Error(), obsidian.ObsidianConfigVaultNotFoundError)
 	})
+
+	t.Run("Vault given by folder does not read Obsidian config", func(t *testing.T) {
+		// Arrange
+		obsidian.ObsidianConfigFile = func() (string, error) {
+			return "", errors.New("no Obsidian config")
+		}
+		vaultDir := t.TempDir()
+		vault := obsidian.Vault{Dir: vaultDir}
+		// Act
+		vaultPath, err := vault.Path()
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, vaultDir, vaultPath)
+	})
+
+	t.Run("Vault folder does not exist", func(t *testing.T) {
+		// Arrange
+		vault := obsidian.Vault{Dir: filepath.Join(t.TempDir(), "missing")}
+		// Act
+		_, err := vault.Path()
+		// Assert
+		assert.ErrorIs(t, err, obsidian.ErrVaultFolderNotFound)
+	})
+}
+
+// writeObsidianConfig points the obsidian package at an obsidian.json with the
+// given content for the rest of the test.
+func writeObsidianConfig(t *testing.T, content string) {
+	t.Helper()
+	configFile := mocks.CreateMockObsidianConfigFile(t)
+	if err := os.WriteFile(configFile, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create obsidian.json file: %v", err)
+	}
+	original := obsidian.ObsidianConfigFile
+	obsidian.ObsidianConfigFile = func() (string, error) {
+		return configFile, nil
+	}
+	t.Cleanup(func() { obsidian.ObsidianConfigFile = original })
+}
+
+func TestListVaults(t *testing.T) {
+	t.Run("Lists vaults by name and marks the default", func(t *testing.T) {
+		// Arrange
+		writeObsidianConfig(t, `{"vaults": {
+			"b2": {"path": "/home/me/work", "ts": 1, "open": true},
+			"a1": {"path": "/home/me/notes"}
+		}}`)
+		originalCliConfigPath := obsidian.CliConfigPath
+		defer func() { obsidian.CliConfigPath = originalCliConfigPath }()
+		cliConfigDir, cliConfigFile := mocks.CreateMockCliConfigDirectories(t)
+		obsidian.CliConfigPath = func() (string, string, error) {
+			return cliConfigDir, cliConfigFile, nil
+		}
+		assert.NoError(t, os.WriteFile(cliConfigFile, []byte(`{"default_vault_name":"work"}`), 0644))
+		// Act
+		vaults, err := obsidian.ListVaults()
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []obsidian.VaultInfo{
+			{ID: "a1", Name: "notes", Path: "/home/me/notes"},
+			{ID: "b2", Name: "work", Path: "/home/me/work", Open: true, Default: true},
+		}, vaults)
+	})
+}
+
+func TestVaultID(t *testing.T) {
+	writeObsidianConfig(t, `{"vaults": {
+		"a1": {"path": "/home/me/notes"},
+		"b2": {"path": "/home/me/archive/notes"},
+		"c3": {"path": "/home/me/work"}
+	}}`)
+
+	tests := []struct {
+		name     string
+		vault    obsidian.Vault
+		expected string
+	}{
+		{"by folder name", obsidian.Vault{Name: "work"}, "c3"},
+		{"by full path", obsidian.Vault{Name: "/home/me/archive/notes"}, "b2"},
+		{"by ID", obsidian.Vault{Name: "a1"}, "a1"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Act
+			id, err := test.vault.ID()
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, id)
+		})
+	}
+
+	t.Run("Vault given by path is not registered", func(t *testing.T) {
+		// Arrange
+		vault := obsidian.Vault{Dir: t.TempDir()}
+		// Act
+		_, err := vault.ID()
+		// Assert
+		assert.ErrorIs(t, err, obsidian.ErrVaultNotRegistered)
+	})
 }
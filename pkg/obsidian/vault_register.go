@@ -0,0 +1,182 @@
+package obsidian
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// obsidianConfigDir is the folder that makes a folder an Obsidian vault.
+const obsidianConfigDir = ".obsidian"
+
+// vaultConfigFiles are the settings files of a new vault. Obsidian fills in the
+// defaults when it first opens the vault.
+var vaultConfigFiles = []string{"app.json", "appearance.json"}
+
+// InitVault makes the folder at dir, created if needed, a vault by adding an
+// .obsidian folder with empty settings. It returns the absolute path of the
+// vault. A folder that already has an .obsidian folder is left unchanged.
+func InitVault(dir string) (string, error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	configDir := filepath.Join(absDir, obsidianConfigDir)
+	if _, err := os.Stat(configDir); err == nil {
+		return "", fmt.Errorf("%s: %s", VaultAlreadyInitializedError, absDir)
+	}
+	if err := os.MkdirAll(configDir, os.ModePerm); err != nil {
+		return "", newWriteError(VaultWriteError, configDir, err)
+	}
+	for _, name := range vaultConfigFiles {
+		if err := writeFileAtomic(filepath.Join(configDir, name), []byte("{}"), 0644, VaultWriteError); err != nil {
+			return "", err
+		}
+	}
+	return absDir, nil
+}
+
+// RegisterVault adds the vault folder at dir to Obsidian's config under a new
+// random ID, the way Obsidian does when a folder is opened as a vault. The config
+// is created if Obsidian has not written it yet. A folder that is already
+// registered keeps its ID.
+func RegisterVault(dir string) (VaultInfo, error) {
+	absDir, err := vaultDir(dir)
+	if err != nil {
+		return VaultInfo{}, err
+	}
+
+	obsidianConfig, err := readObsidianConfig()
+	if err != nil {
+		return VaultInfo{}, err
+	}
+	vaults, err := obsidianConfig.vaults()
+	if err != nil {
+		return VaultInfo{}, err
+	}
+	for id, raw := range vaults {
+		vault := ObsidianVault{}
+		if json.Unmarshal(raw, &vault) == nil && vault.Path == absDir {
+			return VaultInfo{ID: id, Name: vaultFolderName(absDir), Path: absDir, Open: vault.Open}, nil
+		}
+	}
+
+	id, err := newVaultID(vaults)
+	if err != nil {
+		return VaultInfo{}, err
+	}
+	vaults[id], err = json.Marshal(ObsidianVault{Path: absDir, Ts: time.Now().UnixMilli()})
+	if err != nil {
+		return VaultInfo{}, errors.New(ObsidianConfigWriteError)
+	}
+	if err := obsidianConfig.write(vaults); err != nil {
+		return VaultInfo{}, err
+	}
+	return VaultInfo{ID: id, Name: vaultFolderName(absDir), Path: absDir}, nil
+}
+
+// UnregisterVault removes a vault, given by ID, folder name or path, from
+// Obsidian's config. The vault folder is not touched.
+func UnregisterVault(name string) (VaultInfo, error) {
+	known, err := obsidianVaults()
+	if err != nil {
+		return VaultInfo{}, err
+	}
+	vault, err := findVault(known, name)
+	if err != nil {
+		return VaultInfo{}, err
+	}
+
+	obsidianConfig, err := readObsidianConfig()
+	if err != nil {
+		return VaultInfo{}, err
+	}
+	vaults, err := obsidianConfig.vaults()
+	if err != nil {
+		return VaultInfo{}, err
+	}
+	delete(vaults, vault.ID)
+	if err := obsidianConfig.write(vaults); err != nil {
+		return VaultInfo{}, err
+	}
+	return vault, nil
+}
+
+// obsidianConfig is the content of obsidian.json kept as raw JSON, so settings
+// the CLI does not know survive when the vaults are changed.
+type obsidianConfig struct {
+	file   string
+	fields map[string]json.RawMessage
+}
+
+func readObsidianConfig() (*obsidianConfig, error) {
+	file, err := ObsidianConfigFile()
+	if err != nil {
+		return nil, err
+	}
+
+	c := &obsidianConfig{file: file, fields: map[string]json.RawMessage{}}
+	content, err := os.ReadFile(file)
+	if errors.Is(err, os.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, ErrObsidianConfigNotFound
+	}
+	if err := json.Unmarshal(content, &c.fields); err != nil || c.fields == nil {
+		return nil, errors.New(ObsidianConfigParseError)
+	}
+	return c, nil
+}
+
+// vaults returns the vault entries keyed by ID, each kept as raw JSON.
+func (c *obsidianConfig) vaults() (map[string]json.RawMessage, error) {
+	vaults := map[string]json.RawMessage{}
+	if raw, ok := c.fields["vaults"]; ok {
+		if err := json.Unmarshal(raw, &vaults); err != nil {
+			return nil, errors.New(ObsidianConfigParseError)
+		}
+	}
+	if vaults == nil {
+		vaults = map[string]json.RawMessage{}
+	}
+	return vaults, nil
+}
+
+func (c *obsidianConfig) write(vaults map[string]json.RawMessage) error {
+	raw, err := json.Marshal(vaults)
+	if err != nil {
+		return errors.New(ObsidianConfigWriteError)
+	}
+	c.fields["vaults"] = raw
+	content, err := json.Marshal(c.fields)
+	if err != nil {
+		return errors.New(ObsidianConfigWriteError)
+	}
+
+	dir := filepath.Dir(c.file)
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return newWriteError(ObsidianConfigWriteError, dir, err)
+	}
+	return writeFileAtomic(c.file, content, 0644, ObsidianConfigWriteError)
+}
+
+// newVaultID returns a random ID of 16 hex digits, the form Obsidian uses, that
+// no vault has yet.
+func newVaultID(vaults map[string]json.RawMessage) (string, error) {
+	for {
+		b := make([]byte, 8)
+		if _, err := rand.Read(b); err != nil {
+			return "", err
+		}
+		id := hex.EncodeToString(b)
+		if _, taken := vaults[id]; !taken {
+			return id, nil
+		}
+	}
+}
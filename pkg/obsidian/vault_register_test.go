@@ -0,0 +1,135 @@
+package obsidian_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInitVault(t *testing.T) {
+	t.Run("Creates the vault folder with an Obsidian config", func(t *testing.T) {
+		// Arrange
+		dir := filepath.Join(t.TempDir(), "notes")
+		// Act
+		vaultPath, err := obsidian.InitVault(dir)
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, dir, vaultPath)
+		assert.Equal(t, "{}", readVaultFile(t, filepath.Join(dir, ".obsidian", "app.json")))
+	})
+
+	t.Run("Refuses a folder that is already a vault", func(t *testing.T) {
+		// Arrange
+		dir := t.TempDir()
+		writeVaultFiles(t, dir, map[string]string{".obsidian/app.json": `{"vimMode":true}`})
+		// Act
+		_, err := obsidian.InitVault(dir)
+		// Assert
+		assert.ErrorContains(t, err, obsidian.VaultAlreadyInitializedError)
+		assert.Equal(t, `{"vimMode":true}`, readVaultFile(t, filepath.Join(dir, ".obsidian", "app.json")))
+	})
+}
+
+func TestRegisterVault(t *testing.T) {
+	t.Run("Adds the vault and keeps the rest of the config", func(t *testing.T) {
+		// Arrange
+		writeObsidianConfig(t, `{"vaults":{"a1":{"path":"/home/me/work","ts":1,"open":true}},"frame":"hidden"}`)
+		dir := t.TempDir()
+		// Act
+		vault, err := obsidian.RegisterVault(dir)
+		// Assert
+		assert.NoError(t, err)
+		assert.Regexp(t, regexp.MustCompile(`^[0-9a-f]{16}$`), vault.ID)
+		assert.Equal(t, dir, vault.Path)
+		config := readObsidianConfig(t)
+		assert.JSONEq(t, `"hidden"`, string(config["frame"]))
+		var vaults map[string]obsidian.ObsidianVault
+		assert.NoError(t, json.Unmarshal(config["vaults"], &vaults))
+		assert.Equal(t, obsidian.ObsidianVault{Path: "/home/me/work", Ts: 1, Open: true}, vaults["a1"])
+		assert.Equal(t, dir, vaults[vault.ID].Path)
+		assert.NotZero(t, vaults[vault.ID].Ts)
+	})
+
+	t.Run("Keeps the ID of a registered vault", func(t *testing.T) {
+		// Arrange
+		dir := t.TempDir()
+		writeObsidianConfig(t, `{"vaults":{"a1":{"path":`+jsonString(dir)+`}}}`)
+		// Act
+		vault, err := obsidian.RegisterVault(dir)
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "a1", vault.ID)
+	})
+
+	t.Run("Creates Obsidian's config", func(t *testing.T) {
+		// Arrange
+		configFile := filepath.Join(t.TempDir(), "obsidian", "obsidian.json")
+		original := obsidian.ObsidianConfigFile
+		defer func() { obsidian.ObsidianConfigFile = original }()
+		obsidian.ObsidianConfigFile = func() (string, error) {
+			return configFile, nil
+		}
+		dir := t.TempDir()
+		// Act
+		vault, err := obsidian.RegisterVault(dir)
+		// Assert
+		assert.NoError(t, err)
+		vaults, err := obsidian.ListVaults()
+		assert.NoError(t, err)
+		assert.Equal(t, []obsidian.VaultInfo{vault}, vaults)
+	})
+
+	t.Run("Folder does not exist", func(t *testing.T) {
+		// Arrange
+		writeObsidianConfig(t, `{"vaults":{}}`)
+		// Act
+		_, err := obsidian.RegisterVault(filepath.Join(t.TempDir(), "missing"))
+		// Assert
+		assert.ErrorIs(t, err, obsidian.ErrVaultFolderNotFound)
+	})
+}
+
+func TestUnregisterVault(t *testing.T) {
+	t.Run("Removes the vault by name and keeps the rest of the config", func(t *testing.T) {
+		// Arrange
+		writeObsidianConfig(t, `{"vaults":{"a1":{"path":"/home/me/work"},"b2":{"path":"/home/me/notes","ts":2}},"frame":"hidden"}`)
+		// Act
+		vault, err := obsidian.UnregisterVault("work")
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "a1", vault.ID)
+		config := readObsidianConfig(t)
+		assert.JSONEq(t, `{"b2":{"path":"/home/me/notes","ts":2}}`, string(config["vaults"]))
+		assert.JSONEq(t, `"hidden"`, string(config["frame"]))
+	})
+
+	t.Run("Vault not found", func(t *testing.T) {
+		// Arrange
+		writeObsidianConfig(t, `{"vaults":{"a1":{"path":"/home/me/work"}}}`)
+		// Act
+		_, err := obsidian.UnregisterVault("notes")
+		// Assert
+		assert.ErrorIs(t, err, obsidian.ErrVaultNotFound)
+	})
+}
+
+func readObsidianConfig(t *testing.T) map[string]json.RawMessage {
+	t.Helper()
+	configFile, err := obsidian.ObsidianConfigFile()
+	assert.NoError(t, err)
+	content, err := os.ReadFile(configFile)
+	assert.NoError(t, err)
+	config := map[string]json.RawMessage{}
+	assert.NoError(t, json.Unmarshal(content, &config))
+	return config
+}
+
+func jsonString(s string) string {
+	b, _ := json.Marshal(s)
+	return string(b)
+}